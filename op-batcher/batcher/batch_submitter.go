@@ -10,14 +10,17 @@ import (
 	"time"
 
 	gethrpc "github.com/ethereum/go-ethereum/rpc"
-	"github.com/urfave/cli"
+	"github.com/urfave/cli/v2"
 
 	"github.com/ethereum-optimism/optimism/op-batcher/flags"
 	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
 	"github.com/ethereum-optimism/optimism/op-batcher/rpc"
+	"github.com/ethereum-optimism/optimism/op-service/cliapp"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	oppprof "github.com/ethereum-optimism/optimism/op-service/pprof"
 	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 )
 
 const (
@@ -26,6 +29,21 @@ const (
 	defaultDialTimeout = 5 * time.Second
 )
 
+// newServices composes the components of the batcher. They start in order: the
+// tx manager, the background servers and then the batcher itself. They stop in
+// reverse, so that the batcher drains its remaining frames while the metrics and
+// pprof servers are still up, and the tx manager closes last, after the
+// remaining in-flight sends got the close timeout to finish.
+func newServices(txMgr, batcher cliapp.Lifecycle, servers ...cliapp.Lifecycle) *cliapp.Group {
+	var g cliapp.Group
+	g.Add(txMgr)
+	for _, s := range servers {
+		g.Add(s)
+	}
+	g.Add(batcher)
+	return &g
+}
+
 // Main is the entrypoint into the Batch Submitter. This method returns a
 // closure that executes the service and blocks until the service exits. The use
 // of a closure allows the parameters bound to the top-level main package, e.g.
@@ -41,6 +59,14 @@ func Main(version string, cliCtx *cli.Context) error {
 
 	l := oplog.NewLogger(cfg.LogConfig)
 	m := metrics.NewMetrics("default")
+	if cfg.MetricsConfig.CountersFile != "" {
+		store, err := opmetrics.LoadCounterStore(cfg.MetricsConfig.CountersFile)
+		if err != nil {
+			return err
+		}
+		m.PersistCounters(store)
+		defer store.Start(l, opmetrics.CounterFlushInterval)()
+	}
 	l.Info("Initializing Batch Submitter")
 
 	batchSubmitter, err := NewBatchSubmitterFromCLIConfig(cfg, l, m)
@@ -49,44 +75,62 @@ func Main(version string, cliCtx *cli.Context) error {
 		return err
 	}
 
-	if !cfg.Stopped {
-		if err := batchSubmitter.Start(); err != nil {
-			l.Error("Unable to start Batch Submitter", "error", err)
-			return err
-		}
-	}
-
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel() // Stop pprof and metrics only after main loop returns
-	defer batchSubmitter.StopIfRunning(context.Background())
-
+	var servers []cliapp.Lifecycle
 	pprofConfig := cfg.PprofConfig
 	if pprofConfig.Enabled {
 		l.Info("starting pprof", "addr", pprofConfig.ListenAddr, "port", pprofConfig.ListenPort)
-		go func() {
-			if err := oppprof.ListenAndServe(ctx, pprofConfig.ListenAddr, pprofConfig.ListenPort); err != nil {
-				l.Error("error starting pprof", "err", err)
-			}
-		}()
+		servers = append(servers, cliapp.Background("pprof", l, func(ctx context.Context) error {
+			return oppprof.ListenAndServe(ctx, pprofConfig.ListenAddr, pprofConfig.ListenPort, pprofConfig.Auth.ServerOptions()...)
+		}))
+	}
+	if pprofConfig.PushEndpoint != "" {
+		l.Info("starting continuous profiling", "endpoint", pprofConfig.PushEndpoint, "interval", pprofConfig.PushInterval)
+		servers = append(servers, cliapp.Background("pprof push", l, func(ctx context.Context) error {
+			return oppprof.Push(ctx, l, "op-batcher", pprofConfig.PushEndpoint, pprofConfig.PushInterval)
+		}))
 	}
 
 	metricsCfg := cfg.MetricsConfig
 	if metricsCfg.Enabled {
 		l.Info("starting metrics server", "addr", metricsCfg.ListenAddr, "port", metricsCfg.ListenPort)
-		go func() {
-			if err := m.Serve(ctx, metricsCfg.ListenAddr, metricsCfg.ListenPort); err != nil {
-				l.Error("error starting metrics server", err)
-			}
-		}()
-		m.StartBalanceMetrics(ctx, l, batchSubmitter.L1Client, batchSubmitter.TxManager.From())
+		servers = append(servers, cliapp.Background("metrics", l, func(ctx context.Context) error {
+			return m.Serve(ctx, metricsCfg.ListenAddr, metricsCfg.ListenPort, metricsCfg.Auth.ServerOptions()...)
+		}), cliapp.Background("balance metrics", l, func(ctx context.Context) error {
+			return m.MonitorBalance(ctx, l, batchSubmitter.L1Client, batchSubmitter.TxManager.From())
+		}))
 	}
 
+	txMgr := cliapp.Funcs(nil, func(ctx context.Context) error {
+		ctx, cancel := context.WithTimeout(ctx, txmgr.DefaultCloseTimeout)
+		defer cancel()
+		return batchSubmitter.TxManager.Close(ctx)
+	})
+	batcher := cliapp.Funcs(func(context.Context) error {
+		if cfg.Stopped {
+			return nil
+		}
+		return batchSubmitter.Start()
+	}, func(ctx context.Context) error {
+		batchSubmitter.StopIfRunning(ctx)
+		return nil
+	})
+	services := newServices(txMgr, batcher, servers...)
+	if err := services.Start(context.Background()); err != nil {
+		return fmt.Errorf("error starting services: %w", err)
+	}
+	defer func() {
+		if err := services.Stop(context.Background()); err != nil {
+			l.Error("Error stopping services", "err", err)
+		}
+	}()
+
 	rpcCfg := cfg.RPCConfig
 	server := oprpc.NewServer(
 		rpcCfg.ListenAddr,
 		rpcCfg.ListenPort,
 		version,
 		oprpc.WithLogger(l),
+		oprpc.WithHTTPHandler(StatusPath, batchSubmitter.StatusHandler()),
 	)
 	if rpcCfg.EnableAdmin {
 		server.AddAPI(gethrpc.API{
@@ -95,8 +139,13 @@ func Main(version string, cliCtx *cli.Context) error {
 		})
 		l.Info("Admin RPC enabled")
 	}
+	if txMgr, ok := batchSubmitter.TxManager.(*txmgr.SimpleTxManager); ok {
+		server.AddAPI(gethrpc.API{
+			Namespace: txmgr.DebugNamespace,
+			Service:   txmgr.NewDebugAPI(txMgr),
+		})
+	}
 	if err := server.Start(); err != nil {
-		cancel()
 		return fmt.Errorf("error starting RPC server: %w", err)
 	}
 
@@ -0,0 +1,33 @@
+package batcher
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/cliapp"
+)
+
+// TestServicesOrder asserts that the batcher stops before the servers, so that
+// metrics and pprof stay up while it drains, and that the tx manager closes last.
+func TestServicesOrder(t *testing.T) {
+	var events []string
+	record := func(name string) cliapp.Lifecycle {
+		return cliapp.Funcs(func(context.Context) error {
+			events = append(events, "start "+name)
+			return nil
+		}, func(context.Context) error {
+			events = append(events, "stop "+name)
+			return nil
+		})
+	}
+
+	services := newServices(record("txmgr"), record("batcher"), record("pprof"), record("metrics"))
+	require.NoError(t, services.Start(context.Background()))
+	require.NoError(t, services.Stop(context.Background()))
+	require.Equal(t, []string{
+		"start txmgr", "start pprof", "start metrics", "start batcher",
+		"stop batcher", "stop metrics", "stop pprof", "stop txmgr",
+	}, events)
+}
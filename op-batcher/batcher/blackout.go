@@ -0,0 +1,152 @@
+package batcher
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const day = 24 * time.Hour
+
+// BlackoutWindow is a period during which the batcher holds submissions, e.g.
+// during a coordinated L1 protocol upgrade. It is either a one-off window with
+// absolute Start and End, or a window recurring daily or weekly, at UTC times.
+type BlackoutWindow struct {
+	// Start and End of a one-off window.
+	Start, End time.Time
+
+	recurring bool
+	// weekday of a weekly window, nil for a daily window
+	weekday *time.Weekday
+	// from and to are offsets from midnight. If to is before from, the window
+	// extends past midnight.
+	from, to time.Duration
+}
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+// ParseBlackoutWindow parses a blackout window in one of the formats
+//   - "<RFC3339 start>/<RFC3339 end>" for a one-off window,
+//   - "daily HH:MM-HH:MM" for a window every day, or
+//   - "<mon|tue|...> HH:MM-HH:MM" for a window every week,
+//
+// where recurring times are in UTC.
+func ParseBlackoutWindow(s string) (BlackoutWindow, error) {
+	if start, end, ok := strings.Cut(s, "/"); ok {
+		w := BlackoutWindow{}
+		var err error
+		if w.Start, err = time.Parse(time.RFC3339, start); err != nil {
+			return BlackoutWindow{}, fmt.Errorf("invalid blackout window start: %w", err)
+		}
+		if w.End, err = time.Parse(time.RFC3339, end); err != nil {
+			return BlackoutWindow{}, fmt.Errorf("invalid blackout window end: %w", err)
+		}
+		if !w.End.After(w.Start) {
+			return BlackoutWindow{}, fmt.Errorf("blackout window %q ends before it starts", s)
+		}
+		return w, nil
+	}
+
+	days, times, ok := strings.Cut(s, " ")
+	if !ok {
+		return BlackoutWindow{}, fmt.Errorf("invalid blackout window %q", s)
+	}
+	w := BlackoutWindow{recurring: true}
+	if days = strings.ToLower(days); days != "daily" {
+		wd, ok := weekdays[days]
+		if !ok {
+			return BlackoutWindow{}, fmt.Errorf("invalid blackout window day %q", days)
+		}
+		w.weekday = &wd
+	}
+	from, to, ok := strings.Cut(times, "-")
+	if !ok {
+		return BlackoutWindow{}, fmt.Errorf("invalid blackout window times %q", times)
+	}
+	var err error
+	if w.from, err = parseTimeOfDay(from); err != nil {
+		return BlackoutWindow{}, err
+	}
+	if w.to, err = parseTimeOfDay(to); err != nil {
+		return BlackoutWindow{}, err
+	}
+	if w.from == w.to {
+		return BlackoutWindow{}, fmt.Errorf("blackout window %q is empty", s)
+	}
+	return w, nil
+}
+
+func parseTimeOfDay(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid blackout window time %q: %w", s, err)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// occurrence returns the current occurrence of the window, or the next one if
+// it is not active at now. ok is false if the window has passed for good.
+func (w BlackoutWindow) occurrence(now time.Time) (start, end time.Time, ok bool) {
+	if !w.recurring {
+		return w.Start, w.End, now.Before(w.End)
+	}
+	now = now.UTC()
+	length := w.to - w.from
+	if length < 0 {
+		length += day
+	}
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	// an occurrence that started yesterday may still be active
+	for d := -1; d <= 7; d++ {
+		start := midnight.AddDate(0, 0, d).Add(w.from)
+		if w.weekday != nil && start.Weekday() != *w.weekday {
+			continue
+		}
+		if end := start.Add(length); now.Before(end) {
+			return start, end, true
+		}
+	}
+	return time.Time{}, time.Time{}, false
+}
+
+// Blackouts is a set of blackout windows.
+type Blackouts []BlackoutWindow
+
+// maxMergedBlackouts bounds how many adjacent blackout occurrences are merged,
+// so that windows covering all of the time don't loop forever.
+const maxMergedBlackouts = 100
+
+// Status returns whether a blackout is active at now and when it ends. If none is
+// active, until is the start of the next blackout, zero if there is none.
+// Overlapping and adjacent windows are merged into one blackout.
+func (b Blackouts) Status(now time.Time) (active bool, until time.Time) {
+	if until, active = b.activeUntil(now); active {
+		for i := 0; i < maxMergedBlackouts; i++ {
+			next, ok := b.activeUntil(until)
+			if !ok {
+				break
+			}
+			until = next
+		}
+		return true, until
+	}
+	for _, w := range b {
+		if start, _, ok := w.occurrence(now); ok && (until.IsZero() || start.Before(until)) {
+			until = start
+		}
+	}
+	return false, until
+}
+
+// activeUntil returns the latest end of the windows active at now.
+func (b Blackouts) activeUntil(now time.Time) (until time.Time, active bool) {
+	for _, w := range b {
+		if start, end, ok := w.occurrence(now); ok && !now.Before(start) && end.After(until) {
+			until, active = end, true
+		}
+	}
+	return until, active
+}
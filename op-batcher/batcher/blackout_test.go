@@ -0,0 +1,72 @@
+package batcher
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func mustParseBlackout(t *testing.T, s string) BlackoutWindow {
+	w, err := ParseBlackoutWindow(s)
+	require.NoError(t, err)
+	return w
+}
+
+func TestParseBlackoutWindow(t *testing.T) {
+	for _, s := range []string{
+		"2023-05-01T10:00:00Z/2023-05-01T12:00:00Z",
+		"daily 02:00-03:30",
+		"Sat 23:00-01:00",
+	} {
+		_, err := ParseBlackoutWindow(s)
+		require.NoError(t, err, s)
+	}
+	for _, s := range []string{
+		"",
+		"2023-05-01T12:00:00Z/2023-05-01T10:00:00Z",
+		"2023-05-01/2023-05-02",
+		"someday 02:00-03:00",
+		"daily 02:00",
+		"daily 02:00-25:00",
+		"daily 02:00-02:00",
+	} {
+		_, err := ParseBlackoutWindow(s)
+		require.Error(t, err, s)
+	}
+}
+
+func TestBlackoutsStatus(t *testing.T) {
+	at := func(s string) time.Time {
+		ts, err := time.Parse(time.RFC3339, s)
+		require.NoError(t, err)
+		return ts
+	}
+	// 2023-05-06 is a Saturday
+	b := Blackouts{
+		mustParseBlackout(t, "daily 02:00-03:00"),
+		mustParseBlackout(t, "daily 03:00-03:30"),
+		mustParseBlackout(t, "sat 23:00-01:00"),
+		mustParseBlackout(t, "2023-05-10T12:00:00Z/2023-05-10T14:00:00Z"),
+	}
+
+	active, until := b.Status(at("2023-05-06T01:00:00Z"))
+	require.False(t, active)
+	require.Equal(t, at("2023-05-06T02:00:00Z"), until)
+
+	active, until = b.Status(at("2023-05-06T02:15:00Z"))
+	require.True(t, active)
+	require.Equal(t, at("2023-05-06T03:30:00Z"), until, "adjacent windows are merged")
+
+	active, until = b.Status(at("2023-05-07T00:30:00Z"))
+	require.True(t, active, "weekly window extends past midnight")
+	require.Equal(t, at("2023-05-07T01:00:00Z"), until)
+
+	active, until = b.Status(at("2023-05-10T12:30:00Z"))
+	require.True(t, active)
+	require.Equal(t, at("2023-05-10T14:00:00Z"), until)
+
+	active, until = Blackouts{mustParseBlackout(t, "2023-05-10T12:00:00Z/2023-05-10T14:00:00Z")}.Status(at("2023-05-11T00:00:00Z"))
+	require.False(t, active)
+	require.True(t, until.IsZero(), "no blackout scheduled anymore")
+}
@@ -2,8 +2,10 @@ package batcher
 
 import (
 	"bytes"
+	"compress/zlib"
 	"errors"
 	"fmt"
+	"io"
 	"math"
 	"math/big"
 	"math/rand"
@@ -748,3 +750,56 @@ func blockBatchRlpSize(t *testing.T, b *types.Block) int {
 	require.NoError(t, batch.EncodeRLP(&buf), "RLP-encoding batch")
 	return buf.Len()
 }
+
+// TestChannelBuilder_FrameVectors ensures that the batcher transaction data
+// produced for the shared frame test vectors matches the expected encoding.
+func TestChannelBuilder_FrameVectors(t *testing.T) {
+	for _, v := range dtest.FrameVectors {
+		v := v
+		t.Run(v.Name, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, v.Frame.MarshalBinary(&buf))
+			td := txData{frame: frameData{data: buf.Bytes()}}
+			require.Equal(t, v.TxDataBytes(), td.Bytes())
+		})
+	}
+}
+
+// TestChannelBuilder_ChannelVectors ensures that the channel builder encodes the
+// batches of the shared channel test vectors into the expected channel data.
+// The compressed bytes themselves are not compared because the exact zlib
+// output is an implementation detail, only the decompressed stream is.
+func TestChannelBuilder_ChannelVectors(t *testing.T) {
+	for _, v := range dtest.ChannelVectors {
+		v := v
+		t.Run(v.Name, func(t *testing.T) {
+			cb, err := newChannelBuilder(defaultTestChannelConfig)
+			require.NoError(t, err)
+			for i := range v.Batches {
+				_, err := cb.co.AddBatch(&v.Batches[i])
+				require.NoError(t, err)
+			}
+			cb.Close()
+			require.NoError(t, cb.OutputFrames())
+
+			var channelData []byte
+			for cb.HasFrame() {
+				td := txData{frame: cb.NextFrame()}
+				frames, err := derive.ParseFrames(td.Bytes())
+				require.NoError(t, err)
+				require.Len(t, frames, 1)
+				channelData = append(channelData, frames[0].Data...)
+			}
+
+			require.Equal(t, decompress(t, v.CompressedBytes()), decompress(t, channelData))
+		})
+	}
+}
+
+func decompress(t *testing.T, data []byte) []byte {
+	r, err := zlib.NewReader(bytes.NewReader(data))
+	require.NoError(t, err)
+	out, err := io.ReadAll(r)
+	require.NoError(t, err)
+	return out
+}
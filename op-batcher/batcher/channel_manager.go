@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"math"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
 	"github.com/ethereum-optimism/optimism/op-node/eth"
@@ -42,6 +43,9 @@ type channelManager struct {
 	// Set of confirmed txID -> inclusion block. For determining if the channel is timed out
 	confirmedTransactions map[txID]eth.BlockID
 
+	// L1 blocks in which the batch data of recent L2 blocks confirmed
+	sla slaTracker
+
 	// if set to true, prevents production of any new channel frames
 	closed bool
 }
@@ -117,10 +121,28 @@ func (s *channelManager) TxConfirmed(id txID, inclusionBlock eth.BlockID) {
 	if s.pendingChannelIsFullySubmitted() {
 		s.metr.RecordChannelFullySubmitted(s.pendingChannel.ID())
 		s.log.Info("Channel is fully submitted", "id", s.pendingChannel.ID())
+		s.recordConfirmedBlocks(time.Now())
 		s.clearPendingChannel()
 	}
 }
 
+// recordConfirmedBlocks records that the batch data of all blocks of the fully
+// submitted pending channel confirmed, in the latest L1 block a frame of the
+// channel was included in.
+func (s *channelManager) recordConfirmedBlocks(now time.Time) {
+	var l1Block eth.BlockID
+	for _, inclusionBlock := range s.confirmedTransactions {
+		if inclusionBlock.Number >= l1Block.Number {
+			l1Block = inclusionBlock
+		}
+	}
+	for _, block := range s.pendingChannel.Blocks() {
+		delay := now.Sub(time.Unix(int64(block.Time()), 0))
+		s.sla.record(blockConfirmation{l2Block: eth.ToBlockID(block), l1Block: l1Block, delay: delay})
+		s.metr.RecordL2BlockConfirmed(delay)
+	}
+}
+
 // clearPendingChannel resets all pending state back to an initialized but empty state.
 // TODO: Create separate "pending" state
 func (s *channelManager) clearPendingChannel() {
@@ -174,6 +196,7 @@ func (s *channelManager) nextTxData() (txData, error) {
 	id := txdata.ID()
 
 	s.log.Trace("returning next tx data", "id", id)
+	s.metr.RecordFrameSent(len(frame.data), int(s.cfg.MaxFrameSize))
 	s.pendingTransactions[id] = txdata
 	return txdata, nil
 }
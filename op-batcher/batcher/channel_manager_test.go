@@ -505,3 +505,33 @@ func TestChannelManagerCloseAllTxsFailed(t *testing.T) {
 	_, err = m.TxData(eth.BlockID{})
 	require.ErrorIs(err, io.EOF, "Expected closed channel manager to produce no more tx data")
 }
+
+// TestChannelManagerRecordsConfirmedBlocks ensures that the L1 block in which
+// the batch data of an L2 block confirmed is recorded, once its channel is
+// fully submitted.
+func TestChannelManagerRecordsConfirmedBlocks(t *testing.T) {
+	require := require.New(t)
+	log := testlog.Logger(t, log.LvlCrit)
+	m := NewChannelManager(log, metrics.NoopMetrics,
+		ChannelConfig{
+			TargetFrameSize:  0,
+			MaxFrameSize:     100,
+			ApproxComprRatio: 1.0,
+			ChannelTimeout:   1000,
+		})
+	a := newMiniL2Block(0)
+	require.NoError(m.AddL2Block(a))
+
+	txdata, err := m.TxData(eth.BlockID{})
+	require.NoError(err)
+	_, ok := m.sla.latest()
+	require.False(ok, "no block should be recorded before its batch data confirmed")
+
+	l1Block := eth.BlockID{Number: 5, Hash: common.Hash{0x05}}
+	m.TxConfirmed(txdata.ID(), l1Block)
+	c, ok := m.sla.latest()
+	require.True(ok)
+	require.Equal(eth.ToBlockID(a), c.l2Block)
+	require.Equal(l1Block, c.l1Block)
+	require.Positive(c.delay)
+}
@@ -1,23 +1,34 @@
 package batcher
 
 import (
+	"errors"
+	"fmt"
 	"time"
 
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/log"
-	"github.com/urfave/cli"
+	"github.com/hashicorp/go-multierror"
+	"github.com/urfave/cli/v2"
 
 	"github.com/ethereum-optimism/optimism/op-batcher/flags"
 	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
 	"github.com/ethereum-optimism/optimism/op-batcher/rpc"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/sources"
+	opservice "github.com/ethereum-optimism/optimism/op-service"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	oppprof "github.com/ethereum-optimism/optimism/op-service/pprof"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 )
 
+// PauseSource reports whether the system has been paused by the guardian,
+// e.g. the OptimismPortal contract.
+type PauseSource interface {
+	Paused(opts *bind.CallOpts) (bool, error)
+}
+
 type Config struct {
 	log        log.Logger
 	metr       metrics.Metricer
@@ -33,6 +44,13 @@ type Config struct {
 	// RollupConfig is queried at startup
 	Rollup *rollup.Config
 
+	// Portal is checked for a guardian pause before every submission round.
+	// It is optional, submission never halts if it is nil.
+	Portal PauseSource
+
+	// Blackouts are the windows during which submission is held.
+	Blackouts Blackouts
+
 	// Channel builder parameters
 	Channel ChannelConfig
 }
@@ -96,6 +114,14 @@ type CLIConfig struct {
 
 	Stopped bool
 
+	// PortalAddress is the optional address of the OptimismPortal, whose
+	// guardian pause halts submission.
+	PortalAddress string
+
+	// BlackoutWindows are the unparsed windows during which submission is held,
+	// see [ParseBlackoutWindow].
+	BlackoutWindows []string
+
 	TxMgrConfig   txmgr.CLIConfig
 	RPCConfig     rpc.CLIConfig
 	LogConfig     oplog.CLIConfig
@@ -103,43 +129,70 @@ type CLIConfig struct {
 	PprofConfig   oppprof.CLIConfig
 }
 
+// Check validates the config and reports all violations at once.
 func (c CLIConfig) Check() error {
-	if err := c.RPCConfig.Check(); err != nil {
-		return err
+	var result *multierror.Error
+	if err := opservice.CheckRPCURL(c.L1EthRpc); err != nil {
+		result = multierror.Append(result, fmt.Errorf("L1 RPC: %w", err))
 	}
-	if err := c.LogConfig.Check(); err != nil {
-		return err
+	if err := opservice.CheckRPCURL(c.L2EthRpc); err != nil {
+		result = multierror.Append(result, fmt.Errorf("L2 RPC: %w", err))
 	}
-	if err := c.MetricsConfig.Check(); err != nil {
-		return err
+	if err := opservice.CheckRPCURL(c.RollupRpc); err != nil {
+		result = multierror.Append(result, fmt.Errorf("rollup RPC: %w", err))
 	}
-	if err := c.PprofConfig.Check(); err != nil {
-		return err
+	if c.PortalAddress != "" {
+		if err := opservice.CheckNonZeroAddress(c.PortalAddress); err != nil {
+			result = multierror.Append(result, fmt.Errorf("portal address: %w", err))
+		}
 	}
-	if err := c.TxMgrConfig.Check(); err != nil {
-		return err
+	for _, w := range c.BlackoutWindows {
+		if _, err := ParseBlackoutWindow(w); err != nil {
+			result = multierror.Append(result, err)
+		}
 	}
-	return nil
+	if c.PollInterval <= 0 {
+		result = multierror.Append(result, errors.New("poll interval must be positive"))
+	}
+	if c.TargetL1TxSize > c.MaxL1TxSize {
+		result = multierror.Append(result, fmt.Errorf("target L1 tx size (%d) must not exceed max L1 tx size (%d)", c.TargetL1TxSize, c.MaxL1TxSize))
+	}
+	if c.TargetNumFrames < 1 {
+		result = multierror.Append(result, errors.New("target number of frames must be at least 1"))
+	}
+	if c.ApproxComprRatio <= 0 || c.ApproxComprRatio > 1 {
+		result = multierror.Append(result, fmt.Errorf("approximate compression ratio must be in (0, 1], got %v", c.ApproxComprRatio))
+	}
+	for _, sub := range []interface{ Check() error }{
+		c.RPCConfig, c.LogConfig, c.MetricsConfig, c.PprofConfig, c.TxMgrConfig,
+	} {
+		if err := sub.Check(); err != nil {
+			result = multierror.Append(result, err)
+		}
+	}
+	return result.ErrorOrNil()
 }
 
 // NewConfig parses the Config from the provided flags or environment variables.
 func NewConfig(ctx *cli.Context) CLIConfig {
 	return CLIConfig{
 		/* Required Flags */
-		L1EthRpc:        ctx.GlobalString(flags.L1EthRpcFlag.Name),
-		L2EthRpc:        ctx.GlobalString(flags.L2EthRpcFlag.Name),
-		RollupRpc:       ctx.GlobalString(flags.RollupRpcFlag.Name),
-		SubSafetyMargin: ctx.GlobalUint64(flags.SubSafetyMarginFlag.Name),
-		PollInterval:    ctx.GlobalDuration(flags.PollIntervalFlag.Name),
+		L1EthRpc:        ctx.String(flags.L1EthRpcFlag.Name),
+		L2EthRpc:        ctx.String(flags.L2EthRpcFlag.Name),
+		RollupRpc:       ctx.String(flags.RollupRpcFlag.Name),
+		SubSafetyMargin: ctx.Uint64(flags.SubSafetyMarginFlag.Name),
+		PollInterval:    ctx.Duration(flags.PollIntervalFlag.Name),
 
 		/* Optional Flags */
-		MaxPendingTransactions: ctx.GlobalUint64(flags.MaxPendingTransactionsFlag.Name),
-		MaxChannelDuration:     ctx.GlobalUint64(flags.MaxChannelDurationFlag.Name),
-		MaxL1TxSize:            ctx.GlobalUint64(flags.MaxL1TxSizeBytesFlag.Name),
-		TargetL1TxSize:         ctx.GlobalUint64(flags.TargetL1TxSizeBytesFlag.Name),
-		TargetNumFrames:        ctx.GlobalInt(flags.TargetNumFramesFlag.Name),
-		ApproxComprRatio:       ctx.GlobalFloat64(flags.ApproxComprRatioFlag.Name),
-		Stopped:                ctx.GlobalBool(flags.StoppedFlag.Name),
+		MaxPendingTransactions: ctx.Uint64(flags.MaxPendingTransactionsFlag.Name),
+		MaxChannelDuration:     ctx.Uint64(flags.MaxChannelDurationFlag.Name),
+		MaxL1TxSize:            ctx.Uint64(flags.MaxL1TxSizeBytesFlag.Name),
+		TargetL1TxSize:         ctx.Uint64(flags.TargetL1TxSizeBytesFlag.Name),
+		TargetNumFrames:        ctx.Int(flags.TargetNumFramesFlag.Name),
+		ApproxComprRatio:       ctx.Float64(flags.ApproxComprRatioFlag.Name),
+		Stopped:                ctx.Bool(flags.StoppedFlag.Name),
+		PortalAddress:          ctx.String(flags.PortalAddressFlag.Name),
+		BlackoutWindows:        ctx.StringSlice(flags.BlackoutWindowFlag.Name),
 		TxMgrConfig:            txmgr.ReadCLIConfig(ctx),
 		RPCConfig:              rpc.ReadCLIConfig(ctx),
 		LogConfig:              oplog.ReadCLIConfig(ctx),
@@ -7,13 +7,17 @@ import (
 	"io"
 	"math/big"
 	_ "net/http/pprof"
+	"strconv"
 	"sync"
 	"time"
 
 	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
 	"github.com/ethereum-optimism/optimism/op-node/eth"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
@@ -38,8 +42,15 @@ type BatchSubmitter struct {
 	// lastStoredBlock is the last block loaded into `state`. If it is empty it should be set to the l2 safe head.
 	lastStoredBlock eth.BlockID
 	lastL1Tip       eth.L1BlockRef
+	// halted is set while submission is halted because of a guardian pause.
+	halted bool
+	// blackedOut is set while submission is held during a blackout window.
+	blackedOut bool
 
 	state *channelManager
+
+	statusLock sync.Mutex
+	status     Status
 }
 
 // NewBatchSubmitterFromCLIConfig initializes the BatchSubmitter, gathering any resources
@@ -95,6 +106,22 @@ func NewBatchSubmitterFromCLIConfig(cfg CLIConfig, l log.Logger, m metrics.Metri
 		},
 	}
 
+	if cfg.PortalAddress != "" {
+		portal, err := bindings.NewOptimismPortalCaller(common.HexToAddress(cfg.PortalAddress), l1Client)
+		if err != nil {
+			return nil, err
+		}
+		batcherCfg.Portal = portal
+	}
+
+	for _, w := range cfg.BlackoutWindows {
+		window, err := ParseBlackoutWindow(w)
+		if err != nil {
+			return nil, err
+		}
+		batcherCfg.Blackouts = append(batcherCfg.Blackouts, window)
+	}
+
 	// Validate the batcher config
 	if err := batcherCfg.Check(); err != nil {
 		return nil, err
@@ -296,6 +323,10 @@ func (l *BatchSubmitter) loop() {
 	for {
 		select {
 		case <-ticker.C:
+			if l.submissionHeld(l.shutdownCtx, time.Now()) {
+				l.updateStatus()
+				continue
+			}
 			if err := l.loadBlocksIntoState(l.shutdownCtx); errors.Is(err, ErrReorg) {
 				err := l.state.Close()
 				if err != nil {
@@ -303,22 +334,91 @@ func (l *BatchSubmitter) loop() {
 				}
 				l.publishStateToL1(queue, receiptsCh, true)
 				l.state.Clear()
+				l.updateStatus()
 				continue
 			}
 			l.publishStateToL1(queue, receiptsCh, false)
+			l.updateStatus()
 		case r := <-receiptsCh:
 			l.handleReceipt(r)
+			l.updateStatus()
 		case <-l.shutdownCtx.Done():
-			err := l.state.Close()
-			if err != nil {
-				l.log.Error("error closing the channel manager", "err", err)
-			}
-			l.publishStateToL1(queue, receiptsCh, true)
+			l.drainState(queue, receiptsCh)
 			return
 		}
 	}
 }
 
+// drainState closes the channel manager and submits all remaining data, when the
+// batcher is stopped. It is skipped while submission is halted by a guardian
+// pause or held by a blackout window, so that stopping the batcher doesn't post
+// the pending channel. The blocks are loaded again after a restart.
+func (l *BatchSubmitter) drainState(queue *txmgr.Queue[txData], receiptsCh chan txmgr.TxReceipt[txData]) {
+	// the shutdown context is done, but the kill context bounds the drain
+	if l.submissionHeld(l.killCtx, time.Now()) {
+		l.log.Warn("Submission held, not draining the channel manager", "paused", l.halted, "blackout", l.blackedOut)
+		return
+	}
+	if err := l.state.Close(); err != nil {
+		l.log.Error("error closing the channel manager", "err", err)
+	}
+	l.publishStateToL1(queue, receiptsCh, true)
+}
+
+// submissionHeld checks whether submission is halted by a guardian pause or held
+// by a blackout window. Both are checked every time, so that the blackout metrics
+// and the transition logs stay current while the portal is paused.
+func (l *BatchSubmitter) submissionHeld(ctx context.Context, now time.Time) bool {
+	paused := l.isPaused(ctx)
+	blackout := l.inBlackout(now)
+	return paused || blackout
+}
+
+// isPaused checks whether the guardian has paused the system, in which case no new
+// data should be submitted until humans have investigated. Halting and resuming is
+// logged once per transition. If the check fails, the previous state is kept.
+func (l *BatchSubmitter) isPaused(ctx context.Context) bool {
+	if l.Portal == nil {
+		return false
+	}
+	ctx, cancel := context.WithTimeout(ctx, l.NetworkTimeout)
+	defer cancel()
+	paused, err := l.Portal.Paused(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		l.log.Warn("Failed to check whether the portal is paused", "err", err)
+		return l.halted
+	}
+	if paused && !l.halted {
+		l.log.Warn("Portal paused by guardian, halting batch submission")
+	} else if !paused && l.halted {
+		l.log.Info("Portal unpaused, resuming batch submission")
+	}
+	l.halted = paused
+	return paused
+}
+
+// inBlackout checks whether submission is held because of a blackout window.
+// Loaded blocks and pending channels are kept, so submission continues where
+// it left off once the blackout is over.
+func (l *BatchSubmitter) inBlackout(now time.Time) bool {
+	if len(l.Blackouts) == 0 {
+		return false
+	}
+	active, until := l.Blackouts.Status(now)
+	var countdown time.Duration
+	if !until.IsZero() {
+		countdown = until.Sub(now)
+	}
+	l.metr.RecordBlackout(active, countdown)
+	if active && !l.blackedOut {
+		l.log.Warn("Blackout window started, holding batch submission", "until", until)
+	} else if !active && l.blackedOut {
+		l.log.Info("Blackout window ended, resuming batch submission")
+	}
+	l.blackedOut = active
+	return active
+}
+
 // publishStateToL1 loops through the block data loaded into `state` and
 // submits the associated data to the L1 in the form of channel frames.
 func (l *BatchSubmitter) publishStateToL1(queue *txmgr.Queue[txData], receiptsCh chan txmgr.TxReceipt[txData], drain bool) {
@@ -393,6 +493,11 @@ func (l *BatchSubmitter) sendTransaction(txdata txData, queue *txmgr.Queue[txDat
 		To:       &l.Rollup.BatchInboxAddress,
 		TxData:   data,
 		GasLimit: intrinsicGas,
+		Label:    txdata.ID().chID.String(),
+		Labels: map[string]string{
+			"channel": txdata.ID().chID.String(),
+			"frame":   strconv.Itoa(int(txdata.ID().frameNumber)),
+		},
 	}
 	queue.Send(txdata, candidate, receiptsCh)
 }
@@ -425,6 +530,7 @@ func (l *BatchSubmitter) recordConfirmedTx(id txID, receipt *types.Receipt) {
 	l.log.Info("Transaction confirmed", "tx_hash", receipt.TxHash, "status", receipt.Status, "block_hash", receipt.BlockHash, "block_number", receipt.BlockNumber)
 	l1block := eth.BlockID{Number: receipt.BlockNumber.Uint64(), Hash: receipt.BlockHash}
 	l.state.TxConfirmed(id, l1block)
+	l.recordConfirmedL1Block(l1block)
 }
 
 // l1Tip gets the current L1 tip as a L1BlockRef. The passed context is assumed
@@ -0,0 +1,168 @@
+package batcher
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+)
+
+type fakePauseSource struct {
+	paused bool
+	err    error
+}
+
+func (f *fakePauseSource) Paused(*bind.CallOpts) (bool, error) {
+	return f.paused, f.err
+}
+
+// TestBatchSubmitter_IsPaused asserts that submission halts while the portal is
+// paused, resumes once it is unpaused, and keeps its state if the check fails.
+func TestBatchSubmitter_IsPaused(t *testing.T) {
+	portal := &fakePauseSource{}
+	l := &BatchSubmitter{Config: Config{
+		log:            testlog.Logger(t, log.LvlCrit),
+		NetworkTimeout: time.Second,
+		Portal:         portal,
+	}}
+	ctx := context.Background()
+
+	require.False(t, l.isPaused(ctx))
+
+	portal.paused = true
+	require.True(t, l.isPaused(ctx))
+
+	portal.err = errors.New("rpc down")
+	require.True(t, l.isPaused(ctx), "keeps halting on errors")
+
+	portal.paused, portal.err = false, nil
+	require.False(t, l.isPaused(ctx))
+
+	l.Portal = nil
+	require.False(t, l.isPaused(ctx), "never halts without a portal")
+}
+
+// recordingTxManager records the candidates it is asked to send.
+type recordingTxManager struct {
+	txmgr.TxManager
+	mu   sync.Mutex
+	sent []txmgr.TxCandidate
+}
+
+func (m *recordingTxManager) Send(_ context.Context, candidate txmgr.TxCandidate) (*types.Receipt, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sent = append(m.sent, candidate)
+	return &types.Receipt{}, nil
+}
+
+// newDrainingBatchSubmitter returns a batch submitter with a loaded L2 block, as
+// it is when stopped mid-channel.
+func newDrainingBatchSubmitter(t *testing.T, portal PauseSource, blackouts ...BlackoutWindow) (*BatchSubmitter, *recordingTxManager) {
+	lgr := testlog.Logger(t, log.LvlCrit)
+	txMgr := &recordingTxManager{}
+	l := &BatchSubmitter{
+		Config: Config{
+			log:            lgr,
+			metr:           metrics.NoopMetrics,
+			NetworkTimeout: time.Second,
+			Portal:         portal,
+			Blackouts:      blackouts,
+		},
+		txMgr:   txMgr,
+		killCtx: context.Background(),
+		state:   NewChannelManager(lgr, metrics.NoopMetrics, ChannelConfig{}),
+	}
+	block := types.NewBlock(&types.Header{Number: big.NewInt(7)}, nil, nil, nil, nil)
+	require.NoError(t, l.state.AddL2Block(block))
+	return l, txMgr
+}
+
+// TestBatchSubmitter_NoDrainWhilePaused asserts that stopping the batcher while
+// the portal is paused doesn't submit the pending channel.
+func TestBatchSubmitter_NoDrainWhilePaused(t *testing.T) {
+	l, txMgr := newDrainingBatchSubmitter(t, &fakePauseSource{paused: true})
+	queue := txmgr.NewQueue[txData](context.Background(), txMgr, 1)
+
+	l.drainState(queue, make(chan txmgr.TxReceipt[txData]))
+	queue.Wait()
+	require.Empty(t, txMgr.sent, "no tx sent on stop while paused")
+	require.False(t, l.state.closed, "pending channel left open")
+}
+
+// TestBatchSubmitter_UpdateStatus asserts that the status snapshot reflects the
+// channel manager state.
+func TestBatchSubmitter_UpdateStatus(t *testing.T) {
+	lgr := testlog.Logger(t, log.LvlCrit)
+	l := &BatchSubmitter{
+		Config: Config{log: lgr},
+		state:  NewChannelManager(lgr, metrics.NoopMetrics, ChannelConfig{}),
+	}
+
+	l.updateStatus()
+	require.Equal(t, Status{}, l.status)
+
+	block := types.NewBlock(&types.Header{Number: big.NewInt(7)}, nil, nil, nil, nil)
+	require.NoError(t, l.state.AddL2Block(block))
+	require.NoError(t, l.state.ensurePendingChannel(eth.BlockID{}))
+	l.state.pendingTransactions[frameID{frameNumber: 1}] = txData{}
+	l.state.confirmedTransactions[frameID{frameNumber: 0}] = eth.BlockID{Number: 3}
+	l.halted = true
+	l.recordConfirmedL1Block(eth.BlockID{Number: 3})
+	l.updateStatus()
+
+	require.True(t, l.status.Halted)
+	require.Equal(t, l.state.pendingChannel.ID().String(), l.status.Channel)
+	require.Equal(t, 1, l.status.FramesPending)
+	require.Equal(t, 1, l.status.FramesConfirmed)
+	require.Equal(t, &eth.BlockID{Number: 7, Hash: block.Hash()}, l.status.OldestUnbatchedL2Block)
+	require.Equal(t, &eth.BlockID{Number: 3}, l.status.LastConfirmedL1Block)
+}
+
+// TestBatchSubmitter_NoDrainInBlackout asserts that stopping the batcher during a
+// blackout window doesn't submit the pending channel.
+func TestBatchSubmitter_NoDrainInBlackout(t *testing.T) {
+	now := time.Now()
+	l, txMgr := newDrainingBatchSubmitter(t, nil, BlackoutWindow{Start: now.Add(-time.Hour), End: now.Add(time.Hour)})
+	queue := txmgr.NewQueue[txData](context.Background(), txMgr, 1)
+
+	l.drainState(queue, make(chan txmgr.TxReceipt[txData]))
+	queue.Wait()
+	require.Empty(t, txMgr.sent, "no tx sent on stop during a blackout")
+	require.False(t, l.state.closed, "pending channel left open")
+}
+
+// TestBatchSubmitter_SubmissionHeld asserts that blackouts are tracked while the
+// portal is paused.
+func TestBatchSubmitter_SubmissionHeld(t *testing.T) {
+	now := time.Now()
+	portal := &fakePauseSource{paused: true}
+	l, _ := newDrainingBatchSubmitter(t, portal, BlackoutWindow{Start: now.Add(time.Minute), End: now.Add(time.Hour)})
+	ctx := context.Background()
+
+	require.True(t, l.submissionHeld(ctx, now))
+	require.True(t, l.halted)
+	require.False(t, l.blackedOut)
+
+	require.True(t, l.submissionHeld(ctx, now.Add(2*time.Minute)))
+	require.True(t, l.blackedOut, "blackout started while paused")
+
+	portal.paused = false
+	require.True(t, l.submissionHeld(ctx, now.Add(2*time.Minute)))
+	require.False(t, l.halted)
+
+	require.False(t, l.submissionHeld(ctx, now.Add(2*time.Hour)))
+	require.False(t, l.blackedOut)
+}
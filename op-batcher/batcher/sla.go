@@ -0,0 +1,71 @@
+package batcher
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+)
+
+// slaWindow is the number of most recently confirmed L2 blocks over which the
+// batch submission delay statistics are computed.
+const slaWindow = 1000
+
+// blockConfirmation records in which L1 block the batch data of an L2 block
+// confirmed, and how long after the L2 block timestamp.
+type blockConfirmation struct {
+	l2Block eth.BlockID
+	l1Block eth.BlockID
+	delay   time.Duration
+}
+
+// slaTracker keeps the confirmations of the last slaWindow L2 blocks, to report
+// how quickly batch data is posted. It is not safe for concurrent access.
+type slaTracker struct {
+	confs []blockConfirmation
+	// next is the index in confs to overwrite, once the window is full
+	next int
+}
+
+func (t *slaTracker) record(c blockConfirmation) {
+	if len(t.confs) < slaWindow {
+		t.confs = append(t.confs, c)
+		return
+	}
+	t.confs[t.next] = c
+	t.next = (t.next + 1) % slaWindow
+}
+
+// latest returns the most recently recorded confirmation.
+func (t *slaTracker) latest() (blockConfirmation, bool) {
+	if len(t.confs) == 0 {
+		return blockConfirmation{}, false
+	}
+	if len(t.confs) < slaWindow {
+		return t.confs[len(t.confs)-1], true
+	}
+	return t.confs[(t.next+slaWindow-1)%slaWindow], true
+}
+
+// percentiles returns the delays at the given percentiles, between 0 and 1,
+// using the nearest-rank method. They are zero if nothing was recorded yet.
+func (t *slaTracker) percentiles(ps ...float64) []time.Duration {
+	res := make([]time.Duration, len(ps))
+	if len(t.confs) == 0 {
+		return res
+	}
+	delays := make([]time.Duration, len(t.confs))
+	for i, c := range t.confs {
+		delays[i] = c.delay
+	}
+	sort.Slice(delays, func(i, j int) bool { return delays[i] < delays[j] })
+	for i, p := range ps {
+		rank := int(math.Ceil(p * float64(len(delays))))
+		if rank < 1 {
+			rank = 1
+		}
+		res[i] = delays[rank-1]
+	}
+	return res
+}
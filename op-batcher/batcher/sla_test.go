@@ -0,0 +1,33 @@
+package batcher
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+)
+
+func TestSLATracker(t *testing.T) {
+	var tr slaTracker
+	require.Equal(t, []time.Duration{0, 0}, tr.percentiles(0.5, 0.99))
+
+	for i := 1; i <= 100; i++ {
+		tr.record(blockConfirmation{l2Block: eth.BlockID{Number: uint64(i)}, delay: time.Duration(i) * time.Second})
+	}
+	c, ok := tr.latest()
+	require.True(t, ok)
+	require.Equal(t, uint64(100), c.l2Block.Number)
+	require.Equal(t, []time.Duration{50 * time.Second, 99 * time.Second, time.Second}, tr.percentiles(0.5, 0.99, 0))
+
+	// older confirmations drop out of the window
+	for i := 101; i <= slaWindow+100; i++ {
+		tr.record(blockConfirmation{l2Block: eth.BlockID{Number: uint64(i)}, delay: time.Minute})
+	}
+	require.Len(t, tr.confs, slaWindow)
+	c, ok = tr.latest()
+	require.True(t, ok)
+	require.Equal(t, uint64(slaWindow+100), c.l2Block.Number)
+	require.Equal(t, []time.Duration{time.Minute, time.Minute}, tr.percentiles(0.5, 0.99))
+}
@@ -0,0 +1,118 @@
+package batcher
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+)
+
+// StatusPath is where the batch submitter status is served on the RPC server.
+const StatusPath = "/status"
+
+// Status is a snapshot of the batch submitter, served as JSON for simple
+// operator dashboards that don't want to go through Prometheus.
+type Status struct {
+	Running bool `json:"running"`
+	// Halted is set while submission is halted because of a guardian pause.
+	Halted bool `json:"halted"`
+
+	// Channel is the ID of the pending channel, empty if there is none.
+	Channel string `json:"channel,omitempty"`
+	// FramesQueued is the number of frames of the pending channel that were not sent yet.
+	FramesQueued int `json:"frames_queued"`
+	// FramesPending is the number of frames sent to L1, waiting for confirmation.
+	FramesPending int `json:"frames_pending"`
+	// FramesConfirmed is the number of frames of the pending channel confirmed on L1.
+	FramesConfirmed int `json:"frames_confirmed"`
+
+	// OldestUnbatchedL2Block is the oldest loaded L2 block not yet added to a channel.
+	OldestUnbatchedL2Block *eth.BlockID `json:"oldest_unbatched_l2_block,omitempty"`
+	// LastConfirmedL1Block is the L1 block of the last confirmed batcher transaction.
+	LastConfirmedL1Block *eth.BlockID `json:"last_confirmed_l1_block,omitempty"`
+	// LastBatchedL2Block is the latest L2 block whose batch data confirmed on L1,
+	// in BatchedInL1Block.
+	LastBatchedL2Block *eth.BlockID `json:"last_batched_l2_block,omitempty"`
+	BatchedInL1Block   *eth.BlockID `json:"batched_in_l1_block,omitempty"`
+	// BatchDelayP50 and BatchDelayP99 are percentiles of the delay in seconds from
+	// the timestamp of L2 blocks until their batch data confirmed on L1, over the
+	// last 1000 blocks.
+	BatchDelayP50 float64 `json:"batch_delay_p50_seconds"`
+	BatchDelayP99 float64 `json:"batch_delay_p99_seconds"`
+
+	// WalletBalance of the batcher account in wei, omitted if it cannot be fetched.
+	WalletBalance *big.Int `json:"wallet_balance,omitempty"`
+
+	// InflightTxs are the batcher transactions that were sent, but not confirmed yet.
+	InflightTxs []txmgr.InflightTx `json:"inflight_txs,omitempty"`
+}
+
+// updateStatus takes a snapshot of the channel manager state. It must be called
+// from the driver loop, as the channel manager isn't safe for concurrent access.
+func (l *BatchSubmitter) updateStatus() {
+	s := l.state
+	l.statusLock.Lock()
+	defer l.statusLock.Unlock()
+	l.status.Halted = l.halted
+	l.status.Channel = ""
+	l.status.FramesQueued = 0
+	if s.pendingChannel != nil {
+		l.status.Channel = s.pendingChannel.ID().String()
+		l.status.FramesQueued = s.pendingChannel.NumFrames()
+	}
+	l.status.FramesPending = len(s.pendingTransactions)
+	l.status.FramesConfirmed = len(s.confirmedTransactions)
+	l.status.OldestUnbatchedL2Block = nil
+	if len(s.blocks) > 0 {
+		id := eth.ToBlockID(s.blocks[0])
+		l.status.OldestUnbatchedL2Block = &id
+	}
+	if c, ok := s.sla.latest(); ok {
+		l.status.LastBatchedL2Block, l.status.BatchedInL1Block = &c.l2Block, &c.l1Block
+	}
+	delays := s.sla.percentiles(0.5, 0.99)
+	l.status.BatchDelayP50, l.status.BatchDelayP99 = delays[0].Seconds(), delays[1].Seconds()
+}
+
+func (l *BatchSubmitter) recordConfirmedL1Block(id eth.BlockID) {
+	l.statusLock.Lock()
+	defer l.statusLock.Unlock()
+	l.status.LastConfirmedL1Block = &id
+}
+
+// Status returns the latest status snapshot, with the current wallet balance.
+func (l *BatchSubmitter) Status(ctx context.Context) Status {
+	l.mutex.Lock()
+	running := l.running
+	l.mutex.Unlock()
+
+	l.statusLock.Lock()
+	status := l.status
+	l.statusLock.Unlock()
+	status.Running = running
+	if txMgr, ok := l.txMgr.(*txmgr.SimpleTxManager); ok {
+		status.InflightTxs = txMgr.InflightTxs()
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, l.NetworkTimeout)
+	defer cancel()
+	balance, err := l.L1Client.BalanceAt(ctx, l.txMgr.From(), nil)
+	if err != nil {
+		l.log.Warn("Failed to fetch wallet balance for status", "err", err)
+	} else {
+		status.WalletBalance = balance
+	}
+	return status
+}
+
+// StatusHandler serves the [Status] as JSON.
+func (l *BatchSubmitter) StatusHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		enc := json.NewEncoder(w)
+		_ = enc.Encode(l.Status(r.Context()))
+	})
+}
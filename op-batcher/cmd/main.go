@@ -4,11 +4,12 @@ import (
 	"fmt"
 	"os"
 
-	"github.com/urfave/cli"
+	"github.com/urfave/cli/v2"
 
 	"github.com/ethereum-optimism/optimism/op-batcher/batcher"
 	"github.com/ethereum-optimism/optimism/op-batcher/cmd/doc"
 	"github.com/ethereum-optimism/optimism/op-batcher/flags"
+	"github.com/ethereum-optimism/optimism/op-service/cliapp"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	"github.com/ethereum/go-ethereum/log"
 )
@@ -29,11 +30,13 @@ func main() {
 	app.Usage = "Batch Submitter Service"
 	app.Description = "Service for generating and submitting L2 tx batches to L1"
 	app.Action = curryMain(Version)
-	app.Commands = []cli.Command{
+	app.EnableBashCompletion = true
+	app.Commands = []*cli.Command{
 		{
 			Name:        "doc",
 			Subcommands: doc.Subcommands,
 		},
+		cliapp.CompletionCommand(),
 	}
 
 	err := app.Run(os.Args)
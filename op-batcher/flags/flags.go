@@ -2,9 +2,10 @@ package flags
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
-	"github.com/urfave/cli"
+	"github.com/urfave/cli/v2"
 
 	"github.com/ethereum-optimism/optimism/op-batcher/rpc"
 	opservice "github.com/ethereum-optimism/optimism/op-service"
@@ -17,78 +18,106 @@ import (
 
 const envVarPrefix = "OP_BATCHER"
 
+// BatcherCategory groups the flags specific to this service in the help output.
+const BatcherCategory = "BATCHER"
+
 var (
 	// Required flags
-	L1EthRpcFlag = cli.StringFlag{
-		Name:   "l1-eth-rpc",
-		Usage:  "HTTP provider URL for L1",
-		EnvVar: opservice.PrefixEnvVar(envVarPrefix, "L1_ETH_RPC"),
+	L1EthRpcFlag = &cli.StringFlag{
+		Name:     "l1-eth-rpc",
+		Usage:    "HTTP provider URL for L1",
+		EnvVars:  []string{opservice.PrefixEnvVar(envVarPrefix, "L1_ETH_RPC")},
+		Category: opservice.L1Category,
 	}
-	L2EthRpcFlag = cli.StringFlag{
-		Name:   "l2-eth-rpc",
-		Usage:  "HTTP provider URL for L2 execution engine",
-		EnvVar: opservice.PrefixEnvVar(envVarPrefix, "L2_ETH_RPC"),
+	L2EthRpcFlag = &cli.StringFlag{
+		Name:     "l2-eth-rpc",
+		Usage:    "HTTP provider URL for L2 execution engine",
+		EnvVars:  []string{opservice.PrefixEnvVar(envVarPrefix, "L2_ETH_RPC")},
+		Category: opservice.L2Category,
 	}
-	RollupRpcFlag = cli.StringFlag{
-		Name:   "rollup-rpc",
-		Usage:  "HTTP provider URL for Rollup node",
-		EnvVar: opservice.PrefixEnvVar(envVarPrefix, "ROLLUP_RPC"),
+	RollupRpcFlag = &cli.StringFlag{
+		Name:     "rollup-rpc",
+		Usage:    "HTTP provider URL for Rollup node",
+		EnvVars:  []string{opservice.PrefixEnvVar(envVarPrefix, "ROLLUP_RPC")},
+		Category: opservice.L2Category,
 	}
 	// Optional flags
-	SubSafetyMarginFlag = cli.Uint64Flag{
+	SubSafetyMarginFlag = &cli.Uint64Flag{
 		Name: "sub-safety-margin",
 		Usage: "The batcher tx submission safety margin (in #L1-blocks) to subtract " +
 			"from a channel's timeout and sequencing window, to guarantee safe inclusion " +
 			"of a channel on L1.",
-		Value:  10,
-		EnvVar: opservice.PrefixEnvVar(envVarPrefix, "SUB_SAFETY_MARGIN"),
-	}
-	PollIntervalFlag = cli.DurationFlag{
-		Name:   "poll-interval",
-		Usage:  "How frequently to poll L2 for new blocks",
-		Value:  6 * time.Second,
-		EnvVar: opservice.PrefixEnvVar(envVarPrefix, "POLL_INTERVAL"),
-	}
-	MaxPendingTransactionsFlag = cli.Uint64Flag{
-		Name:   "max-pending-tx",
-		Usage:  "The maximum number of pending transactions. 0 for no limit.",
-		Value:  1,
-		EnvVar: opservice.PrefixEnvVar(envVarPrefix, "MAX_PENDING_TX"),
-	}
-	MaxChannelDurationFlag = cli.Uint64Flag{
-		Name:   "max-channel-duration",
-		Usage:  "The maximum duration of L1-blocks to keep a channel open. 0 to disable.",
-		Value:  0,
-		EnvVar: opservice.PrefixEnvVar(envVarPrefix, "MAX_CHANNEL_DURATION"),
-	}
-	MaxL1TxSizeBytesFlag = cli.Uint64Flag{
-		Name:   "max-l1-tx-size-bytes",
-		Usage:  "The maximum size of a batch tx submitted to L1.",
-		Value:  120_000,
-		EnvVar: opservice.PrefixEnvVar(envVarPrefix, "MAX_L1_TX_SIZE_BYTES"),
-	}
-	TargetL1TxSizeBytesFlag = cli.Uint64Flag{
-		Name:   "target-l1-tx-size-bytes",
-		Usage:  "The target size of a batch tx submitted to L1.",
-		Value:  100_000,
-		EnvVar: opservice.PrefixEnvVar(envVarPrefix, "TARGET_L1_TX_SIZE_BYTES"),
-	}
-	TargetNumFramesFlag = cli.IntFlag{
-		Name:   "target-num-frames",
-		Usage:  "The target number of frames to create per channel",
-		Value:  1,
-		EnvVar: opservice.PrefixEnvVar(envVarPrefix, "TARGET_NUM_FRAMES"),
-	}
-	ApproxComprRatioFlag = cli.Float64Flag{
-		Name:   "approx-compr-ratio",
-		Usage:  "The approximate compression ratio (<= 1.0)",
-		Value:  0.4,
-		EnvVar: opservice.PrefixEnvVar(envVarPrefix, "APPROX_COMPR_RATIO"),
-	}
-	StoppedFlag = cli.BoolFlag{
-		Name:   "stopped",
-		Usage:  "Initialize the batcher in a stopped state. The batcher can be started using the admin_startBatcher RPC",
-		EnvVar: opservice.PrefixEnvVar(envVarPrefix, "STOPPED"),
+		Value:    10,
+		EnvVars:  []string{opservice.PrefixEnvVar(envVarPrefix, "SUB_SAFETY_MARGIN")},
+		Category: BatcherCategory,
+	}
+	PollIntervalFlag = &cli.DurationFlag{
+		Name:     "poll-interval",
+		Usage:    "How frequently to poll L2 for new blocks",
+		Value:    6 * time.Second,
+		EnvVars:  []string{opservice.PrefixEnvVar(envVarPrefix, "POLL_INTERVAL")},
+		Category: BatcherCategory,
+	}
+	MaxPendingTransactionsFlag = &cli.Uint64Flag{
+		Name:     "max-pending-tx",
+		Usage:    "The maximum number of pending transactions. 0 for no limit.",
+		Value:    1,
+		EnvVars:  []string{opservice.PrefixEnvVar(envVarPrefix, "MAX_PENDING_TX")},
+		Category: BatcherCategory,
+	}
+	MaxChannelDurationFlag = &cli.Uint64Flag{
+		Name:     "max-channel-duration",
+		Usage:    "The maximum duration of L1-blocks to keep a channel open. 0 to disable.",
+		Value:    0,
+		EnvVars:  []string{opservice.PrefixEnvVar(envVarPrefix, "MAX_CHANNEL_DURATION")},
+		Category: BatcherCategory,
+	}
+	MaxL1TxSizeBytesFlag = &cli.Uint64Flag{
+		Name:     "max-l1-tx-size-bytes",
+		Usage:    "The maximum size of a batch tx submitted to L1.",
+		Value:    120_000,
+		EnvVars:  []string{opservice.PrefixEnvVar(envVarPrefix, "MAX_L1_TX_SIZE_BYTES")},
+		Category: BatcherCategory,
+	}
+	TargetL1TxSizeBytesFlag = &cli.Uint64Flag{
+		Name:     "target-l1-tx-size-bytes",
+		Usage:    "The target size of a batch tx submitted to L1.",
+		Value:    100_000,
+		EnvVars:  []string{opservice.PrefixEnvVar(envVarPrefix, "TARGET_L1_TX_SIZE_BYTES")},
+		Category: BatcherCategory,
+	}
+	TargetNumFramesFlag = &cli.IntFlag{
+		Name:     "target-num-frames",
+		Usage:    "The target number of frames to create per channel",
+		Value:    1,
+		EnvVars:  []string{opservice.PrefixEnvVar(envVarPrefix, "TARGET_NUM_FRAMES")},
+		Category: BatcherCategory,
+	}
+	ApproxComprRatioFlag = &cli.Float64Flag{
+		Name:     "approx-compr-ratio",
+		Usage:    "The approximate compression ratio (<= 1.0)",
+		Value:    0.4,
+		EnvVars:  []string{opservice.PrefixEnvVar(envVarPrefix, "APPROX_COMPR_RATIO")},
+		Category: BatcherCategory,
+	}
+	StoppedFlag = &cli.BoolFlag{
+		Name:     "stopped",
+		Usage:    "Initialize the batcher in a stopped state. The batcher can be started using the admin_startBatcher RPC",
+		EnvVars:  []string{opservice.PrefixEnvVar(envVarPrefix, "STOPPED")},
+		Category: BatcherCategory,
+	}
+	PortalAddressFlag = &cli.StringFlag{
+		Name:     "portal-address",
+		Usage:    "Address of the OptimismPortal contract. If set, submission halts while the guardian has paused the portal, and resumes once it is unpaused",
+		EnvVars:  []string{opservice.PrefixEnvVar(envVarPrefix, "PORTAL_ADDRESS")},
+		Category: BatcherCategory,
+	}
+	BlackoutWindowFlag = &cli.StringSliceFlag{
+		Name: "blackout-window",
+		Usage: "Window during which submission is held, resuming automatically afterwards. Either '<RFC3339 start>/<RFC3339 end>', " +
+			"'daily HH:MM-HH:MM' or '<mon|tue|...> HH:MM-HH:MM' in UTC. Can be repeated. Blackouts must be shorter than the sequencing window",
+		EnvVars:  []string{opservice.PrefixEnvVar(envVarPrefix, "BLACKOUT_WINDOW")},
+		Category: BatcherCategory,
 	}
 	// Legacy Flags
 	SequencerHDPathFlag = txmgr.SequencerHDPathFlag
@@ -110,6 +139,8 @@ var optionalFlags = []cli.Flag{
 	TargetNumFramesFlag,
 	ApproxComprRatioFlag,
 	StoppedFlag,
+	PortalAddressFlag,
+	BlackoutWindowFlag,
 }
 
 func init() {
@@ -126,11 +157,16 @@ func init() {
 // Flags contains the list of configuration options available to the binary.
 var Flags []cli.Flag
 
+// CheckRequired returns an error listing all required flags that are not set.
 func CheckRequired(ctx *cli.Context) error {
+	var missing []string
 	for _, f := range requiredFlags {
-		if !ctx.GlobalIsSet(f.GetName()) {
-			return fmt.Errorf("flag %s is required", f.GetName())
+		if name := f.Names()[0]; !ctx.IsSet(name) {
+			missing = append(missing, name)
 		}
 	}
+	if len(missing) > 0 {
+		return fmt.Errorf("required flags not set: %s", strings.Join(missing, ", "))
+	}
 	return nil
 }
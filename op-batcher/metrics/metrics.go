@@ -2,6 +2,7 @@ package metrics
 
 import (
 	"context"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
@@ -10,6 +11,7 @@ import (
 
 	"github.com/ethereum-optimism/optimism/op-node/eth"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/httputil"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	txmetrics "github.com/ethereum-optimism/optimism/op-service/txmgr/metrics"
 )
@@ -34,9 +36,14 @@ type Metricer interface {
 	RecordChannelFullySubmitted(id derive.ChannelID)
 	RecordChannelTimedOut(id derive.ChannelID)
 
+	RecordFrameSent(frameBytes, maxFrameBytes int)
+
 	RecordBatchTxSubmitted()
 	RecordBatchTxSuccess()
 	RecordBatchTxFailed()
+	RecordL2BlockConfirmed(delay time.Duration)
+
+	RecordBlackout(active bool, countdown time.Duration)
 
 	Document() []opmetrics.DocumentedMetric
 }
@@ -67,7 +74,15 @@ type Metrics struct {
 	ChannelInputBytesTotal  prometheus.Counter
 	ChannelOutputBytesTotal prometheus.Counter
 
+	FrameUtilization        prometheus.Histogram
+	FrameOverheadBytesTotal prometheus.Counter
+
 	BatcherTxEvs opmetrics.EventVec
+
+	L2BlockConfirmationDelay prometheus.Histogram
+
+	BlackoutActive    prometheus.Gauge
+	BlackoutCountdown prometheus.Gauge
 }
 
 var _ Metricer = (*Metrics)(nil)
@@ -157,21 +172,58 @@ func NewMetrics(procName string) *Metrics {
 			Help:      "Total number of compressed output bytes from a channel.",
 		}),
 
+		FrameUtilization: factory.NewHistogram(prometheus.HistogramOpts{
+			Namespace: ns,
+			Name:      "frame_utilization",
+			Help:      "Size of sent frames relative to the max frame size.",
+			Buckets:   prometheus.LinearBuckets(0.1, 0.1, 10),
+		}),
+		FrameOverheadBytesTotal: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "frame_overhead_bytes_total",
+			Help:      "Total number of bytes of sent frames spent on the frame header and version byte, instead of channel data.",
+		}),
+
 		BatcherTxEvs: opmetrics.NewEventVec(factory, ns, "", "batcher_tx", "BatcherTx", []string{"stage"}),
+
+		L2BlockConfirmationDelay: factory.NewHistogram(prometheus.HistogramOpts{
+			Namespace: ns,
+			Name:      "l2_block_confirmation_delay_seconds",
+			Help:      "Delay from the timestamp of L2 blocks until their batch data confirmed on L1.",
+			Buckets:   prometheus.ExponentialBuckets(15, 2, 10),
+		}),
+
+		BlackoutActive: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "blackout_active",
+			Help:      "1 if submission is held because of a blackout window, 0 otherwise.",
+		}),
+		BlackoutCountdown: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "blackout_countdown_seconds",
+			Help:      "Seconds until the active blackout ends, or until the next one starts. 0 if none is scheduled.",
+		}),
 	}
 }
 
-func (m *Metrics) Serve(ctx context.Context, host string, port int) error {
-	return opmetrics.ListenAndServe(ctx, m.registry, host, port)
+// PersistCounters makes the total fees and total output bytes counters continue from,
+// and persist to, the given store. It must be called before the counters are used.
+func (m *Metrics) PersistCounters(store *opmetrics.CounterStore) {
+	m.TxMetrics.PersistCounters(store)
+	m.ChannelOutputBytesTotal = store.Counter("output_bytes_total", m.ChannelOutputBytesTotal)
+}
+
+func (m *Metrics) Serve(ctx context.Context, host string, port int, opts ...httputil.ServerOption) error {
+	return opmetrics.ListenAndServe(ctx, m.registry, host, port, opts...)
 }
 
 func (m *Metrics) Document() []opmetrics.DocumentedMetric {
 	return m.factory.Document()
 }
 
-func (m *Metrics) StartBalanceMetrics(ctx context.Context,
-	l log.Logger, client *ethclient.Client, account common.Address) {
-	opmetrics.LaunchBalanceMetrics(ctx, l, m.registry, m.ns, client, account)
+// MonitorBalance records the balance of the account until ctx is canceled.
+func (m *Metrics) MonitorBalance(ctx context.Context, l log.Logger, client *ethclient.Client, account common.Address) error {
+	return opmetrics.MonitorBalance(ctx, l, m.registry, m.ns, client, account)
 }
 
 // RecordInfo sets a pseudo-metric that contains versioning and
@@ -256,6 +308,16 @@ func (m *Metrics) RecordChannelTimedOut(id derive.ChannelID) {
 	m.ChannelEvs.Record(StageTimedOut)
 }
 
+// RecordFrameSent should be called for every frame handed out for submission,
+// to track how well frames fill the available space.
+func (m *Metrics) RecordFrameSent(frameBytes, maxFrameBytes int) {
+	if maxFrameBytes > 0 {
+		m.FrameUtilization.Observe(float64(frameBytes) / float64(maxFrameBytes))
+	}
+	// the version byte precedes every frame in the tx data
+	m.FrameOverheadBytesTotal.Add(float64(derive.FrameV0OverHeadSize + 1))
+}
+
 func (m *Metrics) RecordBatchTxSubmitted() {
 	m.BatcherTxEvs.Record(TxStageSubmitted)
 }
@@ -267,3 +329,18 @@ func (m *Metrics) RecordBatchTxSuccess() {
 func (m *Metrics) RecordBatchTxFailed() {
 	m.BatcherTxEvs.Record(TxStageFailed)
 }
+
+// RecordL2BlockConfirmed should be called for every L2 block whose batch data
+// confirmed on L1, with the delay since its timestamp.
+func (m *Metrics) RecordL2BlockConfirmed(delay time.Duration) {
+	m.L2BlockConfirmationDelay.Observe(delay.Seconds())
+}
+
+func (m *Metrics) RecordBlackout(active bool, countdown time.Duration) {
+	if active {
+		m.BlackoutActive.Set(1)
+	} else {
+		m.BlackoutActive.Set(0)
+	}
+	m.BlackoutCountdown.Set(countdown.Seconds())
+}
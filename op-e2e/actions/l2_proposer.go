@@ -47,6 +47,12 @@ func (f fakeTxMgr) From() common.Address {
 func (f fakeTxMgr) Send(_ context.Context, _ txmgr.TxCandidate) (*types.Receipt, error) {
 	panic("unimplemented")
 }
+func (f fakeTxMgr) Abort(_ string) int {
+	panic("unimplemented")
+}
+func (f fakeTxMgr) Close(_ context.Context) error {
+	return nil
+}
 
 func NewL2Proposer(t Testing, log log.Logger, cfg *ProposerCfg, l1 *ethclient.Client, rollupCl *sources.RollupClient) *L2Proposer {
 
@@ -63,6 +63,7 @@ type Metricer interface {
 	RecordBandwidth(ctx context.Context, bwc *libp2pmetrics.BandwidthCounter)
 	RecordSequencerBuildingDiffTime(duration time.Duration)
 	RecordSequencerSealingTime(duration time.Duration)
+	RecordClockSkew(layer string, skew time.Duration)
 	Document() []metrics.DocumentedMetric
 	RecordChannelInputBytes(num int)
 	// P2P Metrics
@@ -109,6 +110,8 @@ type Metrics struct {
 	SequencerSealingDurationSeconds prometheus.Histogram
 	SequencerSealingTotal           prometheus.Counter
 
+	ClockSkewSeconds *prometheus.GaugeVec
+
 	UnsafePayloadsBufferLen     prometheus.Gauge
 	UnsafePayloadsBufferMemSize prometheus.Gauge
 
@@ -403,6 +406,14 @@ func NewMetrics(procName string) *Metrics {
 			Help:      "Number of sequencer block sealing jobs",
 		}),
 
+		ClockSkewSeconds: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "clock_skew_seconds",
+			Help:      "Timestamp of the latest head block minus local time, in seconds. Positive values mean the head is ahead of the local clock",
+		}, []string{
+			"layer",
+		}),
+
 		registry: registry,
 		factory:  factory,
 	}
@@ -604,6 +615,12 @@ func (m *Metrics) RecordSequencerSealingTime(duration time.Duration) {
 	m.SequencerSealingDurationSeconds.Observe(float64(duration) / float64(time.Second))
 }
 
+// RecordClockSkew records the difference between the timestamp of the latest head block
+// of the given layer and the local clock.
+func (m *Metrics) RecordClockSkew(layer string, skew time.Duration) {
+	m.ClockSkewSeconds.WithLabelValues(layer).Set(float64(skew) / float64(time.Second))
+}
+
 // Serve starts the metrics server on the given hostname and port.
 // The server will be closed when the passed-in context is cancelled.
 func (m *Metrics) Serve(ctx context.Context, hostname string, port int) error {
@@ -738,6 +755,9 @@ func (n *noopMetricer) RecordSequencerBuildingDiffTime(duration time.Duration) {
 func (n *noopMetricer) RecordSequencerSealingTime(duration time.Duration) {
 }
 
+func (n *noopMetricer) RecordClockSkew(layer string, skew time.Duration) {
+}
+
 func (n *noopMetricer) Document() []metrics.DocumentedMetric {
 	return nil
 }
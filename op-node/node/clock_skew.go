@@ -0,0 +1,78 @@
+package node
+
+import (
+	"context"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+)
+
+const clockSkewPollInterval = 30 * time.Second
+
+type clockSkewL1Source interface {
+	L1BlockRefByLabel(ctx context.Context, label eth.BlockLabel) (eth.L1BlockRef, error)
+}
+
+type clockSkewL2Source interface {
+	L2BlockRefByLabel(ctx context.Context, label eth.BlockLabel) (eth.L2BlockRef, error)
+}
+
+type ClockSkewMetrics interface {
+	RecordClockSkew(layer string, skew time.Duration)
+}
+
+// clockSkewMonitor compares the local clock with the timestamps of the L1 and L2 heads.
+// A head that is older than the local time may just be lagging behind, but a head with
+// a timestamp in the future means that the clocks of this node, the L1 node or the
+// sequencer are not synchronized. Sequencing and validity windows assume they are.
+type clockSkewMonitor struct {
+	log       log.Logger
+	l1        clockSkewL1Source
+	l2        clockSkewL2Source
+	metrics   ClockSkewMetrics
+	threshold time.Duration
+	now       func() time.Time
+}
+
+// check records the skew of each layer and warns if a head is further ahead of the local
+// clock than the threshold.
+func (m *clockSkewMonitor) check(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	if l1Head, err := m.l1.L1BlockRefByLabel(ctx, eth.Unsafe); err != nil {
+		m.log.Warn("failed to fetch L1 head for clock skew check", "err", err)
+	} else {
+		m.record("l1", l1Head.Time, l1Head.ID())
+	}
+	if l2Head, err := m.l2.L2BlockRefByLabel(ctx, eth.Unsafe); err != nil {
+		m.log.Warn("failed to fetch L2 head for clock skew check", "err", err)
+	} else {
+		m.record("l2", l2Head.Time, l2Head.ID())
+	}
+}
+
+func (m *clockSkewMonitor) record(layer string, blockTime uint64, id eth.BlockID) {
+	skew := time.Unix(int64(blockTime), 0).Sub(m.now())
+	m.metrics.RecordClockSkew(layer, skew)
+	if skew > m.threshold {
+		m.log.Warn("head block timestamp is ahead of local clock, check clock synchronization",
+			"layer", layer, "head", id, "skew", skew, "threshold", m.threshold)
+	}
+}
+
+// run checks the clock skew every clockSkewPollInterval until ctx is done.
+func (m *clockSkewMonitor) run(ctx context.Context) {
+	ticker := time.NewTicker(clockSkewPollInterval)
+	defer ticker.Stop()
+	for {
+		m.check(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
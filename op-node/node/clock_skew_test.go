@@ -0,0 +1,53 @@
+package node
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+)
+
+type stubHeads struct {
+	l1 eth.L1BlockRef
+	l2 eth.L2BlockRef
+}
+
+func (s *stubHeads) L1BlockRefByLabel(ctx context.Context, label eth.BlockLabel) (eth.L1BlockRef, error) {
+	return s.l1, nil
+}
+
+func (s *stubHeads) L2BlockRefByLabel(ctx context.Context, label eth.BlockLabel) (eth.L2BlockRef, error) {
+	return s.l2, nil
+}
+
+type skewRecorder map[string]time.Duration
+
+func (r skewRecorder) RecordClockSkew(layer string, skew time.Duration) {
+	r[layer] = skew
+}
+
+func TestClockSkewMonitor(t *testing.T) {
+	now := time.Unix(1000, 0)
+	heads := &stubHeads{
+		l1: eth.L1BlockRef{Number: 10, Time: 988},
+		l2: eth.L2BlockRef{Number: 20, Time: 1030},
+	}
+	rec := make(skewRecorder)
+	m := &clockSkewMonitor{
+		log:       testlog.Logger(t, log.LvlInfo),
+		l1:        heads,
+		l2:        heads,
+		metrics:   rec,
+		threshold: 10 * time.Second,
+		now:       func() time.Time { return now },
+	}
+	m.check(context.Background())
+
+	require.Equal(t, -12*time.Second, rec["l1"])
+	require.Equal(t, 30*time.Second, rec["l2"])
+}
@@ -36,6 +36,10 @@ type Config struct {
 	// Used to poll the L1 for new finalized or safe blocks
 	L1EpochPollInterval time.Duration
 
+	// ClockSkewThreshold is how far the L1 or L2 head may be ahead of the local clock
+	// before a clock skew warning is logged. Zero disables the clock skew monitor.
+	ClockSkewThreshold time.Duration
+
 	// Optional
 	Tracer    Tracer
 	Heartbeat HeartbeatConfig
@@ -92,6 +96,9 @@ func (cfg *Config) Check() error {
 	if err := cfg.Pprof.Check(); err != nil {
 		return fmt.Errorf("pprof config error: %w", err)
 	}
+	if cfg.ClockSkewThreshold < 0 {
+		return errors.New("clock skew threshold must not be negative")
+	}
 	if cfg.P2P != nil {
 		if err := cfg.P2P.Check(); err != nil {
 			return fmt.Errorf("p2p config error: %w", err)
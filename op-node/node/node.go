@@ -40,6 +40,8 @@ type OpNode struct {
 	tracer    Tracer                // tracer to get events for testing/debugging
 	runCfg    *RuntimeConfig        // runtime configurables
 
+	clockSkewThreshold time.Duration // warn when a head block is this far ahead of the local clock, 0 to disable
+
 	// some resources cannot be stopped directly, like the p2p gossipsub router (not our design),
 	// and depend on this ctx to be closed.
 	resourcesCtx   context.Context
@@ -58,6 +60,8 @@ func New(ctx context.Context, cfg *Config, log log.Logger, snapshotLog log.Logge
 		log:        log,
 		appVersion: appVersion,
 		metrics:    m,
+
+		clockSkewThreshold: cfg.ClockSkewThreshold,
 	}
 	// not a context leak, gossipsub is closed with a context.
 	n.resourcesCtx, n.resourcesClose = context.WithCancel(context.Background())
@@ -297,6 +301,18 @@ func (n *OpNode) Start(ctx context.Context) error {
 		n.log.Info("Started L2-RPC sync service")
 	}
 
+	if n.clockSkewThreshold > 0 {
+		m := &clockSkewMonitor{
+			log:       n.log,
+			l1:        n.l1Source,
+			l2:        n.l2Source,
+			metrics:   n.metrics,
+			threshold: n.clockSkewThreshold,
+			now:       time.Now,
+		}
+		go m.run(n.resourcesCtx)
+	}
+
 	return nil
 }
 
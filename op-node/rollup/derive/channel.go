@@ -1,8 +1,10 @@
 package derive
 
 import (
+	"bufio"
 	"bytes"
 	"compress/zlib"
+	"errors"
 	"fmt"
 	"io"
 
@@ -142,21 +144,62 @@ func (ch *Channel) Reader() io.Reader {
 	return io.MultiReader(readers...)
 }
 
+// ErrChannelTooLarge is returned by the batch reader once the decompressed
+// channel data exceeds MaxRLPBytesPerChannel. Batches before the limit are
+// still read, the rest of the channel is ignored.
+var ErrChannelTooLarge = errors.New("decompressed channel data exceeds the maximum size")
+
+// countingReader counts the decompressed bytes consumed by the RLP reader. It
+// implements io.ByteReader, so that the RLP reader doesn't buffer beyond what
+// it consumes.
+type countingReader struct {
+	r *bufio.Reader
+	n uint64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += uint64(n)
+	return n, err
+}
+
+func (c *countingReader) ReadByte() (byte, error) {
+	b, err := c.r.ReadByte()
+	if err == nil {
+		c.n++
+	}
+	return b, err
+}
+
 // BatchReader provides a function that iteratively consumes batches from the reader.
 // The L1Inclusion block is also provided at creation time.
+//
+// Decompression is streamed and stops at MaxRLPBytesPerChannel, so that memory
+// stays bounded regardless of the compression ratio. Exceeding it ends the
+// channel with ErrChannelTooLarge instead of io.EOF.
 func BatchReader(r io.Reader, l1InclusionBlock eth.L1BlockRef) (func() (BatchWithL1InclusionBlock, error), error) {
 	// Setup decompressor stage + RLP reader
 	zr, err := zlib.NewReader(r)
 	if err != nil {
 		return nil, err
 	}
-	rlpReader := rlp.NewStream(zr, MaxRLPBytesPerChannel)
+	cr := &countingReader{r: bufio.NewReader(zr)}
+	rlpReader := rlp.NewStream(cr, MaxRLPBytesPerChannel)
 	// Read each batch iteratively
 	return func() (BatchWithL1InclusionBlock, error) {
 		ret := BatchWithL1InclusionBlock{
 			L1InclusionBlock: l1InclusionBlock,
 		}
 		err := rlpReader.Decode(&ret.Batch)
+		if errors.Is(err, rlp.ErrValueTooLarge) {
+			return ret, fmt.Errorf("%w: %v", ErrChannelTooLarge, err)
+		}
+		// at the top level, the RLP reader reports reaching its limit as io.EOF
+		if err == io.EOF && cr.n >= MaxRLPBytesPerChannel {
+			if _, perr := cr.r.Peek(1); perr == nil {
+				return ret, ErrChannelTooLarge
+			}
+		}
 		return ret, err
 	}, nil
 }
@@ -2,7 +2,11 @@ package derive
 
 import (
 	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
 	"math/big"
+	"math/rand"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -138,3 +142,90 @@ func TestBlockToBatchValidity(t *testing.T) {
 	_, _, err := BlockToBatch(block)
 	require.ErrorContains(t, err, "has no transactions")
 }
+
+// benchBatch returns a batch with numTxs transactions of calldata in which about
+// half of the 32 byte words are zero, so that it compresses like real batches.
+func benchBatch(rng *rand.Rand, numTxs int) *BatchData {
+	batch := &BatchData{BatchV1{Timestamp: 1}}
+	for i := 0; i < numTxs; i++ {
+		tx := make([]byte, 32*16)
+		for w := 0; w < len(tx); w += 32 {
+			if rng.Intn(2) == 0 {
+				_, _ = rng.Read(tx[w : w+32])
+			}
+		}
+		batch.Transactions = append(batch.Transactions, tx)
+	}
+	return batch
+}
+
+// BenchmarkCompression compares the zlib compression levels on batch data that
+// is written in 1KB chunks. The compression ratio is reported as a metric.
+func BenchmarkCompression(b *testing.B) {
+	var data bytes.Buffer
+	if err := rlp.Encode(&data, benchBatch(rand.New(rand.NewSource(1)), 128)); err != nil {
+		b.Fatal(err)
+	}
+	input := data.Bytes()
+	for _, level := range []int{zlib.BestSpeed, zlib.DefaultCompression, zlib.BestCompression} {
+		b.Run(fmt.Sprintf("level=%d", level), func(b *testing.B) {
+			var out bytes.Buffer
+			w, err := zlib.NewWriterLevel(&out, level)
+			if err != nil {
+				b.Fatal(err)
+			}
+			b.SetBytes(int64(len(input)))
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				out.Reset()
+				w.Reset(&out)
+				for off := 0; off < len(input); off += 1024 {
+					end := off + 1024
+					if end > len(input) {
+						end = len(input)
+					}
+					if _, err := w.Write(input[off:end]); err != nil {
+						b.Fatal(err)
+					}
+				}
+				if err := w.Close(); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.ReportMetric(float64(out.Len())/float64(len(input)), "ratio")
+		})
+	}
+}
+
+// BenchmarkChannelOutFrames measures adding a batch to a channel and splitting
+// it into 1KB frames.
+func BenchmarkChannelOutFrames(b *testing.B) {
+	batch := benchBatch(rand.New(rand.NewSource(1)), 128)
+	cout, err := NewChannelOut()
+	if err != nil {
+		b.Fatal(err)
+	}
+	var frame bytes.Buffer
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := cout.Reset(); err != nil {
+			b.Fatal(err)
+		}
+		if _, err := cout.AddBatch(batch); err != nil {
+			b.Fatal(err)
+		}
+		if err := cout.Close(); err != nil {
+			b.Fatal(err)
+		}
+		for {
+			frame.Reset()
+			if _, err := cout.OutputFrame(&frame, 1024); err == io.EOF {
+				break
+			} else if err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
@@ -1,10 +1,16 @@
 package derive
 
 import (
+	"bytes"
+	"compress/zlib"
+	"io"
 	"testing"
 
-	"github.com/ethereum-optimism/optimism/op-node/eth"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/eth"
 )
 
 type frameValidityTC struct {
@@ -99,3 +105,70 @@ func TestFrameValidity(t *testing.T) {
 		t.Run(tc.name, tc.Run)
 	}
 }
+
+func compressChannelData(t require.TestingT, data []byte) []byte {
+	var buf bytes.Buffer
+	w := zlib.NewWriter(&buf)
+	_, err := w.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func TestBatchReader(t *testing.T) {
+	batch := &BatchData{BatchV1{Timestamp: 1, Transactions: []hexutil.Bytes{{0x01}}}}
+	enc, err := rlp.EncodeToBytes(batch)
+	require.NoError(t, err)
+
+	t.Run("batches", func(t *testing.T) {
+		next, err := BatchReader(bytes.NewReader(compressChannelData(t, append(enc, enc...))), eth.L1BlockRef{Number: 7})
+		require.NoError(t, err)
+		for i := 0; i < 2; i++ {
+			b, err := next()
+			require.NoError(t, err)
+			require.Equal(t, uint64(7), b.L1InclusionBlock.Number)
+			require.Equal(t, batch.Timestamp, b.Batch.Timestamp)
+		}
+		_, err = next()
+		require.ErrorIs(t, err, io.EOF)
+	})
+
+	t.Run("oversized batch", func(t *testing.T) {
+		oversized, err := rlp.EncodeToBytes(make([]byte, MaxRLPBytesPerChannel))
+		require.NoError(t, err)
+		next, err := BatchReader(bytes.NewReader(compressChannelData(t, oversized)), eth.L1BlockRef{})
+		require.NoError(t, err)
+		_, err = next()
+		require.ErrorIs(t, err, ErrChannelTooLarge)
+	})
+
+	t.Run("batches past the limit", func(t *testing.T) {
+		data := bytes.Repeat(enc, MaxRLPBytesPerChannel/len(enc)+1)
+		next, err := BatchReader(bytes.NewReader(compressChannelData(t, data)), eth.L1BlockRef{})
+		require.NoError(t, err)
+		for i := 0; i < MaxRLPBytesPerChannel/len(enc); i++ {
+			_, err := next()
+			require.NoError(t, err)
+		}
+		_, err = next()
+		require.ErrorIs(t, err, ErrChannelTooLarge)
+	})
+}
+
+// FuzzBatchReader checks that arbitrary decompressed channel data never makes
+// the batch reader panic, and that it always terminates with an error.
+func FuzzBatchReader(f *testing.F) {
+	f.Add([]byte{})
+	f.Add([]byte{0xc0})
+	f.Add([]byte{0xb9, 0xff, 0xff, 0xff})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		next, err := BatchReader(bytes.NewReader(compressChannelData(t, data)), eth.L1BlockRef{})
+		require.NoError(t, err)
+		for i := 0; ; i++ {
+			require.LessOrEqual(t, i, len(data), "read more batches than input bytes")
+			if _, err := next(); err != nil {
+				return
+			}
+		}
+	})
+}
@@ -195,6 +195,36 @@ func TestParseFramesTruncated(t *testing.T) {
 	require.Empty(t, frames0)
 }
 
+func BenchmarkFrameMarshalBinary(b *testing.B) {
+	frame := randomFrame(rand.New(rand.NewSource(1)), frameWithDataLen(1024))
+	var buf bytes.Buffer
+	b.SetBytes(int64(len(frame.Data)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		buf.Reset()
+		if err := frame.MarshalBinary(&buf); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkParseFrames(b *testing.B) {
+	rng := rand.New(rand.NewSource(1))
+	data, err := txMarshalFrames([]Frame{*randomFrame(rng, frameWithDataLen(1024))})
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := ParseFrames(data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
 // txMarshalFrames creates the tx payload for the given frames, i.e., it first
 // writes the version byte to a buffer and then appends all binary-marshaled
 // frames.
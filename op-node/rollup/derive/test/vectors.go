@@ -0,0 +1,131 @@
+package test
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+)
+
+// The test vectors in this file are shared between the op-batcher encoder
+// tests and the op-node decoder tests. Both sides must agree on every vector,
+// so that the two implementations cannot drift apart unnoticed. Vectors must
+// never be changed in place; add a new vector instead.
+
+// FrameVector is a single frame together with the batcher transaction data
+// that carries it.
+type FrameVector struct {
+	Name  string
+	Frame derive.Frame
+	// TxData is the hex encoded batcher transaction data, i.e. the derivation
+	// version byte followed by the serialized frame.
+	TxData string
+}
+
+// TxDataBytes returns the decoded TxData of the vector.
+func (v *FrameVector) TxDataBytes() []byte {
+	return hexutil.MustDecode(v.TxData)
+}
+
+// ChannelVector is a list of batches together with the compressed channel
+// data that encodes them.
+type ChannelVector struct {
+	Name    string
+	Batches []derive.BatchData
+	// Compressed is the hex encoded, zlib compressed stream of RLP encoded
+	// batches, i.e. the concatenated frame data of a complete channel.
+	Compressed string
+}
+
+// CompressedBytes returns the decoded Compressed data of the vector.
+func (v *ChannelVector) CompressedBytes() []byte {
+	return hexutil.MustDecode(v.Compressed)
+}
+
+var vectorChannelID = derive.ChannelID{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10}
+
+// FrameVectors contains the frame encoding test vectors.
+var FrameVectors = []FrameVector{
+	{
+		Name: "first frame",
+		Frame: derive.Frame{
+			ID:          vectorChannelID,
+			FrameNumber: 0,
+			Data:        []byte{0xde, 0xad, 0xbe, 0xef},
+			IsLast:      false,
+		},
+		TxData: "0x000102030405060708090a0b0c0d0e0f10000000000004deadbeef00",
+	},
+	{
+		Name: "last frame",
+		Frame: derive.Frame{
+			ID:          vectorChannelID,
+			FrameNumber: 1,
+			Data:        []byte{0xc0, 0xff, 0xee},
+			IsLast:      true,
+		},
+		TxData: "0x000102030405060708090a0b0c0d0e0f10000100000003c0ffee01",
+	},
+	{
+		Name: "empty closing frame",
+		Frame: derive.Frame{
+			ID:          derive.ChannelID{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+			FrameNumber: 0,
+			Data:        []byte{},
+			IsLast:      true,
+		},
+		TxData: "0x00ffffffffffffffffffffffffffffffff00000000000001",
+	},
+}
+
+func repeatHash(b byte) (h common.Hash) {
+	for i := range h {
+		h[i] = b
+	}
+	return h
+}
+
+func repeatBytes(b byte, n int) hexutil.Bytes {
+	out := make(hexutil.Bytes, n)
+	for i := range out {
+		out[i] = b
+	}
+	return out
+}
+
+// ChannelVectors contains the channel compression test vectors.
+var ChannelVectors = []ChannelVector{
+	{
+		Name: "single empty batch",
+		Batches: []derive.BatchData{
+			{BatchV1: derive.BatchV1{
+				ParentHash:   repeatHash(0x11),
+				EpochNum:     1,
+				EpochHash:    repeatHash(0x22),
+				Timestamp:    2,
+				Transactions: []hexutil.Bytes{},
+			}},
+		},
+		Compressed: "0x78dadbe1c1f0c375812001c0b8408900603a0000a1cf0aa1",
+	},
+	{
+		Name: "multiple batches with transactions",
+		Batches: []derive.BatchData{
+			{BatchV1: derive.BatchV1{
+				ParentHash:   repeatHash(0x33),
+				EpochNum:     10,
+				EpochHash:    repeatHash(0x44),
+				Timestamp:    1000,
+				Transactions: []hexutil.Bytes{{0xde, 0xad, 0xbe, 0xef}},
+			}},
+			{BatchV1: derive.BatchV1{
+				ParentHash:   repeatHash(0x55),
+				EpochNum:     10,
+				EpochHash:    repeatHash(0x44),
+				Timestamp:    1002,
+				Transactions: []hexutil.Bytes{{0x01}, {0xc0, 0xff, 0xee}, repeatBytes(0xab, 100)},
+			}},
+		},
+		Compressed: "0x78dadbe1cff0c367813101c0b5c0850068627e71b4e5deda7def776c63f8b179412801408c81af7e6433361ff8ff6e47ca6a3a000020ea79d2",
+	},
+}
@@ -0,0 +1,49 @@
+package derive_test
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	dtest "github.com/ethereum-optimism/optimism/op-node/rollup/derive/test"
+)
+
+// TestFrameVectors ensures that the batcher transaction data of the shared
+// test vectors is parsed into the expected frames.
+func TestFrameVectors(t *testing.T) {
+	for _, v := range dtest.FrameVectors {
+		v := v
+		t.Run(v.Name, func(t *testing.T) {
+			frames, err := derive.ParseFrames(v.TxDataBytes())
+			require.NoError(t, err)
+			require.Equal(t, []derive.Frame{v.Frame}, frames)
+		})
+	}
+}
+
+// TestChannelVectors ensures that the compressed channel data of the shared
+// test vectors is decoded into the expected batches.
+func TestChannelVectors(t *testing.T) {
+	for _, v := range dtest.ChannelVectors {
+		v := v
+		t.Run(v.Name, func(t *testing.T) {
+			next, err := derive.BatchReader(bytes.NewReader(v.CompressedBytes()), eth.L1BlockRef{})
+			require.NoError(t, err)
+
+			var batches []derive.BatchData
+			for {
+				b, err := next()
+				if err == io.EOF {
+					break
+				}
+				require.NoError(t, err)
+				batches = append(batches, *b.Batch)
+			}
+			require.Equal(t, v.Batches, batches)
+		})
+	}
+}
@@ -5,14 +5,14 @@ import (
 	"strings"
 	"testing"
 
-	"github.com/urfave/cli"
+	"github.com/urfave/cli/v2"
 )
 
 // TestUniqueFlags asserts that all flag names are unique, to avoid accidental conflicts between the many flags.
 func TestUniqueFlags(t *testing.T) {
 	seenCLI := make(map[string]struct{})
 	for _, flag := range Flags {
-		name := flag.GetName()
+		name := flag.Names()[0]
 		if _, ok := seenCLI[name]; ok {
 			t.Errorf("duplicate flag %s", name)
 			continue
@@ -38,22 +38,22 @@ func TestCorrectEnvVarPrefix(t *testing.T) {
 	for _, flag := range Flags {
 		envVar := envVarForFlag(flag)
 		if envVar == "" {
-			t.Errorf("Failed to find EnvVar for flag %v", flag.GetName())
+			t.Errorf("Failed to find EnvVar for flag %v", flag.Names()[0])
 		}
 		if envVar[:len("OP_PROGRAM_")] != "OP_PROGRAM_" {
-			t.Errorf("Flag %v env var (%v) does not start with OP_PROGRAM_", flag.GetName(), envVar)
+			t.Errorf("Flag %v env var (%v) does not start with OP_PROGRAM_", flag.Names()[0], envVar)
 		}
 		if strings.Contains(envVar, "__") {
-			t.Errorf("Flag %v env var (%v) has duplicate underscores", flag.GetName(), envVar)
+			t.Errorf("Flag %v env var (%v) has duplicate underscores", flag.Names()[0], envVar)
 		}
 	}
 }
 
 func envVarForFlag(flag cli.Flag) string {
-	values := reflect.ValueOf(flag)
-	envVarValue := values.FieldByName("EnvVar")
-	if envVarValue == (reflect.Value{}) {
+	values := reflect.ValueOf(flag).Elem()
+	envVarValue := values.FieldByName("EnvVars")
+	if envVarValue == (reflect.Value{}) || envVarValue.Len() == 0 {
 		return ""
 	}
-	return envVarValue.String()
+	return envVarValue.Index(0).String()
 }
@@ -4,11 +4,13 @@ import (
 	"fmt"
 	"os"
 
-	"github.com/urfave/cli"
+	"github.com/urfave/cli/v2"
 
 	"github.com/ethereum-optimism/optimism/op-proposer/cmd/doc"
+	"github.com/ethereum-optimism/optimism/op-proposer/cmd/verify"
 	"github.com/ethereum-optimism/optimism/op-proposer/flags"
 	"github.com/ethereum-optimism/optimism/op-proposer/proposer"
+	"github.com/ethereum-optimism/optimism/op-service/cliapp"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	"github.com/ethereum/go-ethereum/log"
 )
@@ -29,11 +31,14 @@ func main() {
 	app.Usage = "L2Output Submitter"
 	app.Description = "Service for generating and submitting L2 Output checkpoints to the L2OutputOracle contract"
 	app.Action = curryMain(Version)
-	app.Commands = []cli.Command{
+	app.EnableBashCompletion = true
+	app.Commands = []*cli.Command{
 		{
 			Name:        "doc",
 			Subcommands: doc.Subcommands,
 		},
+		verify.Command,
+		cliapp.CompletionCommand(),
 	}
 
 	err := app.Run(os.Args)
@@ -0,0 +1,95 @@
+package verify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-proposer/flags"
+	"github.com/ethereum-optimism/optimism/op-proposer/proposer"
+	opservice "github.com/ethereum-optimism/optimism/op-service"
+)
+
+var (
+	L2EthRpcFlag = &cli.StringFlag{
+		Name:     "l2-eth-rpc",
+		Usage:    "HTTP provider URL for the local L2 execution engine",
+		Required: true,
+	}
+	L2BlockFlag = &cli.Uint64Flag{
+		Name:     "l2-block",
+		Usage:    "L2 block number to verify. The first output at or after this block is checked",
+		Required: true,
+	}
+	TimeoutFlag = &cli.DurationFlag{
+		Name:  "timeout",
+		Usage: "Timeout for all RPC requests",
+		Value: time.Minute,
+	}
+)
+
+var ErrMismatch = errors.New("output root mismatch")
+
+// Command checks an output root posted to the L2OutputOracle against the local L2 state.
+var Command = &cli.Command{
+	Name:  "verify",
+	Usage: "Verifies a posted output root against the state of a local L2 execution engine",
+	Flags: []cli.Flag{
+		flags.L1EthRpcFlag,
+		flags.L2OOAddressFlag,
+		L2EthRpcFlag,
+		L2BlockFlag,
+		TimeoutFlag,
+	},
+	Action: func(cliCtx *cli.Context) error {
+		for _, f := range []cli.Flag{flags.L1EthRpcFlag, flags.L2OOAddressFlag} {
+			if !cliCtx.IsSet(f.Names()[0]) {
+				return fmt.Errorf("flag %s is required", f.Names()[0])
+			}
+		}
+		if err := opservice.CheckNonZeroAddress(cliCtx.String(flags.L2OOAddressFlag.Name)); err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(cliCtx.Context, cliCtx.Duration(TimeoutFlag.Name))
+		defer cancel()
+
+		l1Client, err := ethclient.DialContext(ctx, cliCtx.String(flags.L1EthRpcFlag.Name))
+		if err != nil {
+			return fmt.Errorf("failed to dial L1: %w", err)
+		}
+		defer l1Client.Close()
+		l2Rpc, err := rpc.DialContext(ctx, cliCtx.String(L2EthRpcFlag.Name))
+		if err != nil {
+			return fmt.Errorf("failed to dial L2: %w", err)
+		}
+		defer l2Rpc.Close()
+
+		l2oo, err := bindings.NewL2OutputOracleCaller(common.HexToAddress(cliCtx.String(flags.L2OOAddressFlag.Name)), l1Client)
+		if err != nil {
+			return err
+		}
+		res, err := proposer.VerifyOutput(ctx, l2oo, l2Rpc, cliCtx.Uint64(L2BlockFlag.Name))
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("output index:   %s\n", res.OutputIndex)
+		fmt.Printf("l2 block:       %d\n", res.L2BlockNumber)
+		fmt.Printf("posted root:    %s\n", res.Posted)
+		fmt.Printf("local root:     %s\n", res.Local)
+		if !res.Match() {
+			fmt.Println("result:         MISMATCH")
+			return ErrMismatch
+		}
+		fmt.Println("result:         match")
+		return nil
+	},
+}
@@ -2,9 +2,10 @@ package flags
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
-	"github.com/urfave/cli"
+	"github.com/urfave/cli/v2"
 
 	opservice "github.com/ethereum-optimism/optimism/op-service"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
@@ -16,35 +17,43 @@ import (
 
 const envVarPrefix = "OP_PROPOSER"
 
+// ProposerCategory groups the flags specific to this service in the help output.
+const ProposerCategory = "PROPOSER"
+
 var (
 	// Required Flags
-	L1EthRpcFlag = cli.StringFlag{
-		Name:   "l1-eth-rpc",
-		Usage:  "HTTP provider URL for L1",
-		EnvVar: opservice.PrefixEnvVar(envVarPrefix, "L1_ETH_RPC"),
+	L1EthRpcFlag = &cli.StringFlag{
+		Name:     "l1-eth-rpc",
+		Usage:    "HTTP provider URL for L1",
+		EnvVars:  []string{opservice.PrefixEnvVar(envVarPrefix, "L1_ETH_RPC")},
+		Category: opservice.L1Category,
 	}
-	RollupRpcFlag = cli.StringFlag{
-		Name:   "rollup-rpc",
-		Usage:  "HTTP provider URL for the rollup node",
-		EnvVar: opservice.PrefixEnvVar(envVarPrefix, "ROLLUP_RPC"),
+	RollupRpcFlag = &cli.StringFlag{
+		Name:     "rollup-rpc",
+		Usage:    "HTTP provider URL for the rollup node",
+		EnvVars:  []string{opservice.PrefixEnvVar(envVarPrefix, "ROLLUP_RPC")},
+		Category: opservice.L2Category,
 	}
-	L2OOAddressFlag = cli.StringFlag{
-		Name:   "l2oo-address",
-		Usage:  "Address of the L2OutputOracle contract",
-		EnvVar: opservice.PrefixEnvVar(envVarPrefix, "L2OO_ADDRESS"),
+	L2OOAddressFlag = &cli.StringFlag{
+		Name:     "l2oo-address",
+		Usage:    "Address of the L2OutputOracle contract",
+		EnvVars:  []string{opservice.PrefixEnvVar(envVarPrefix, "L2OO_ADDRESS")},
+		Category: opservice.L1Category,
 	}
 
 	// Optional flags
-	PollIntervalFlag = cli.DurationFlag{
-		Name:   "poll-interval",
-		Usage:  "How frequently to poll L2 for new blocks",
-		Value:  6 * time.Second,
-		EnvVar: opservice.PrefixEnvVar(envVarPrefix, "POLL_INTERVAL"),
+	PollIntervalFlag = &cli.DurationFlag{
+		Name:     "poll-interval",
+		Usage:    "How frequently to poll L2 for new blocks",
+		Value:    6 * time.Second,
+		EnvVars:  []string{opservice.PrefixEnvVar(envVarPrefix, "POLL_INTERVAL")},
+		Category: ProposerCategory,
 	}
-	AllowNonFinalizedFlag = cli.BoolFlag{
-		Name:   "allow-non-finalized",
-		Usage:  "Allow the proposer to submit proposals for L2 blocks derived from non-finalized L1 blocks.",
-		EnvVar: opservice.PrefixEnvVar(envVarPrefix, "ALLOW_NON_FINALIZED"),
+	AllowNonFinalizedFlag = &cli.BoolFlag{
+		Name:     "allow-non-finalized",
+		Usage:    "Allow the proposer to submit proposals for L2 blocks derived from non-finalized L1 blocks.",
+		EnvVars:  []string{opservice.PrefixEnvVar(envVarPrefix, "ALLOW_NON_FINALIZED")},
+		Category: ProposerCategory,
 	}
 	// Legacy Flags
 	L2OutputHDPathFlag = txmgr.L2OutputHDPathFlag
@@ -62,8 +71,7 @@ var optionalFlags = []cli.Flag{
 }
 
 func init() {
-	requiredFlags = append(requiredFlags, oprpc.CLIFlags(envVarPrefix)...)
-
+	optionalFlags = append(optionalFlags, oprpc.CLIFlags(envVarPrefix)...)
 	optionalFlags = append(optionalFlags, oplog.CLIFlags(envVarPrefix)...)
 	optionalFlags = append(optionalFlags, opmetrics.CLIFlags(envVarPrefix)...)
 	optionalFlags = append(optionalFlags, oppprof.CLIFlags(envVarPrefix)...)
@@ -75,11 +83,16 @@ func init() {
 // Flags contains the list of configuration options available to the binary.
 var Flags []cli.Flag
 
+// CheckRequired returns an error listing all required flags that are not set.
 func CheckRequired(ctx *cli.Context) error {
+	var missing []string
 	for _, f := range requiredFlags {
-		if !ctx.GlobalIsSet(f.GetName()) {
-			return fmt.Errorf("flag %s is required", f.GetName())
+		if name := f.Names()[0]; !ctx.IsSet(name) {
+			missing = append(missing, name)
 		}
 	}
+	if len(missing) > 0 {
+		return fmt.Errorf("required flags not set: %s", strings.Join(missing, ", "))
+	}
 	return nil
 }
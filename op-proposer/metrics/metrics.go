@@ -10,6 +10,7 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/ethereum-optimism/optimism/op-service/httputil"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	txmetrics "github.com/ethereum-optimism/optimism/op-service/txmgr/metrics"
 )
@@ -75,13 +76,19 @@ func NewMetrics(procName string) *Metrics {
 	}
 }
 
-func (m *Metrics) Serve(ctx context.Context, host string, port int) error {
-	return opmetrics.ListenAndServe(ctx, m.registry, host, port)
+// PersistCounters makes the total fees counter continue from, and persist to, the
+// given store. It must be called before any transaction is sent.
+func (m *Metrics) PersistCounters(store *opmetrics.CounterStore) {
+	m.TxMetrics.PersistCounters(store)
 }
 
-func (m *Metrics) StartBalanceMetrics(ctx context.Context,
-	l log.Logger, client *ethclient.Client, account common.Address) {
-	opmetrics.LaunchBalanceMetrics(ctx, l, m.registry, m.ns, client, account)
+func (m *Metrics) Serve(ctx context.Context, host string, port int, opts ...httputil.ServerOption) error {
+	return opmetrics.ListenAndServe(ctx, m.registry, host, port, opts...)
+}
+
+// MonitorBalance records the balance of the account until ctx is canceled.
+func (m *Metrics) MonitorBalance(ctx context.Context, l log.Logger, client *ethclient.Client, account common.Address) error {
+	return opmetrics.MonitorBalance(ctx, l, m.registry, m.ns, client, account)
 }
 
 // RecordInfo sets a pseudo-metric that contains versioning and
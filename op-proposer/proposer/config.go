@@ -1,15 +1,19 @@
 package proposer
 
 import (
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
-	"github.com/urfave/cli"
+	"github.com/hashicorp/go-multierror"
+	"github.com/urfave/cli/v2"
 
 	"github.com/ethereum-optimism/optimism/op-node/sources"
 	"github.com/ethereum-optimism/optimism/op-proposer/flags"
 
+	opservice "github.com/ethereum-optimism/optimism/op-service"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	oppprof "github.com/ethereum-optimism/optimism/op-service/pprof"
@@ -63,36 +67,42 @@ type CLIConfig struct {
 	PprofConfig oppprof.CLIConfig
 }
 
+// Check validates the config and reports all violations at once.
 func (c CLIConfig) Check() error {
-	if err := c.RPCConfig.Check(); err != nil {
-		return err
+	var result *multierror.Error
+	if err := opservice.CheckRPCURL(c.L1EthRpc); err != nil {
+		result = multierror.Append(result, fmt.Errorf("L1 RPC: %w", err))
 	}
-	if err := c.LogConfig.Check(); err != nil {
-		return err
+	if err := opservice.CheckRPCURL(c.RollupRpc); err != nil {
+		result = multierror.Append(result, fmt.Errorf("rollup RPC: %w", err))
 	}
-	if err := c.MetricsConfig.Check(); err != nil {
-		return err
+	if err := opservice.CheckNonZeroAddress(c.L2OOAddress); err != nil {
+		result = multierror.Append(result, fmt.Errorf("L2OutputOracle: %w", err))
 	}
-	if err := c.PprofConfig.Check(); err != nil {
-		return err
+	if c.PollInterval <= 0 {
+		result = multierror.Append(result, errors.New("poll interval must be positive"))
 	}
-	if err := c.TxMgrConfig.Check(); err != nil {
-		return err
+	for _, sub := range []interface{ Check() error }{
+		c.RPCConfig, c.LogConfig, c.MetricsConfig, c.PprofConfig, c.TxMgrConfig,
+	} {
+		if err := sub.Check(); err != nil {
+			result = multierror.Append(result, err)
+		}
 	}
-	return nil
+	return result.ErrorOrNil()
 }
 
 // NewConfig parses the Config from the provided flags or environment variables.
 func NewConfig(ctx *cli.Context) CLIConfig {
 	return CLIConfig{
 		// Required Flags
-		L1EthRpc:     ctx.GlobalString(flags.L1EthRpcFlag.Name),
-		RollupRpc:    ctx.GlobalString(flags.RollupRpcFlag.Name),
-		L2OOAddress:  ctx.GlobalString(flags.L2OOAddressFlag.Name),
-		PollInterval: ctx.GlobalDuration(flags.PollIntervalFlag.Name),
+		L1EthRpc:     ctx.String(flags.L1EthRpcFlag.Name),
+		RollupRpc:    ctx.String(flags.RollupRpcFlag.Name),
+		L2OOAddress:  ctx.String(flags.L2OOAddressFlag.Name),
+		PollInterval: ctx.Duration(flags.PollIntervalFlag.Name),
 		TxMgrConfig:  txmgr.ReadCLIConfig(ctx),
 		// Optional Flags
-		AllowNonFinalized: ctx.GlobalBool(flags.AllowNonFinalizedFlag.Name),
+		AllowNonFinalized: ctx.Bool(flags.AllowNonFinalizedFlag.Name),
 		RPCConfig:         oprpc.ReadCLIConfig(ctx),
 		LogConfig:         oplog.ReadCLIConfig(ctx),
 		MetricsConfig:     opmetrics.ReadCLIConfig(ctx),
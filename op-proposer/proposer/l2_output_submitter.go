@@ -17,13 +17,17 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
-	"github.com/urfave/cli"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+	"github.com/urfave/cli/v2"
 
 	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
 	"github.com/ethereum-optimism/optimism/op-node/eth"
 	"github.com/ethereum-optimism/optimism/op-node/sources"
+	"github.com/ethereum-optimism/optimism/op-proposer/flags"
 	"github.com/ethereum-optimism/optimism/op-proposer/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/cliapp"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	oppprof "github.com/ethereum-optimism/optimism/op-service/pprof"
 	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
@@ -31,9 +35,26 @@ import (
 
 var supportedL2OutputVersion = eth.Bytes32{}
 
+// newServices composes the components of the proposer. They start in order: the
+// tx manager, the background servers and then the proposer itself. They stop in
+// reverse, so that the metrics and pprof servers stay up until the proposer
+// stopped, and the tx manager closes last.
+func newServices(txMgr, proposer cliapp.Lifecycle, servers ...cliapp.Lifecycle) *cliapp.Group {
+	var g cliapp.Group
+	g.Add(txMgr)
+	for _, s := range servers {
+		g.Add(s)
+	}
+	g.Add(proposer)
+	return &g
+}
+
 // Main is the entrypoint into the L2 Output Submitter. This method executes the
 // service and blocks until the service exits.
 func Main(version string, cliCtx *cli.Context) error {
+	if err := flags.CheckRequired(cliCtx); err != nil {
+		return err
+	}
 	cfg := NewConfig(cliCtx)
 	if err := cfg.Check(); err != nil {
 		return fmt.Errorf("invalid CLI flags: %w", err)
@@ -41,6 +62,14 @@ func Main(version string, cliCtx *cli.Context) error {
 
 	l := oplog.NewLogger(cfg.LogConfig)
 	m := metrics.NewMetrics("default")
+	if cfg.MetricsConfig.CountersFile != "" {
+		store, err := opmetrics.LoadCounterStore(cfg.MetricsConfig.CountersFile)
+		if err != nil {
+			return err
+		}
+		m.PersistCounters(store)
+		defer store.Start(l, opmetrics.CounterFlushInterval)()
+	}
 	l.Info("Initializing L2 Output Submitter")
 
 	proposerConfig, err := NewL2OutputSubmitterConfigFromCLIConfig(cfg, l, m)
@@ -55,41 +84,63 @@ func Main(version string, cliCtx *cli.Context) error {
 		return err
 	}
 
-	l.Info("Starting L2 Output Submitter")
-	ctx, cancel := context.WithCancel(context.Background())
-	if err := l2OutputSubmitter.Start(); err != nil {
-		cancel()
-		l.Error("Unable to start L2 Output Submitter", "error", err)
-		return err
-	}
-	defer l2OutputSubmitter.Stop()
-
-	l.Info("L2 Output Submitter started")
+	var servers []cliapp.Lifecycle
 	pprofConfig := cfg.PprofConfig
 	if pprofConfig.Enabled {
 		l.Info("starting pprof", "addr", pprofConfig.ListenAddr, "port", pprofConfig.ListenPort)
-		go func() {
-			if err := oppprof.ListenAndServe(ctx, pprofConfig.ListenAddr, pprofConfig.ListenPort); err != nil {
-				l.Error("error starting pprof", "err", err)
-			}
-		}()
+		servers = append(servers, cliapp.Background("pprof", l, func(ctx context.Context) error {
+			return oppprof.ListenAndServe(ctx, pprofConfig.ListenAddr, pprofConfig.ListenPort, pprofConfig.Auth.ServerOptions()...)
+		}))
+	}
+	if pprofConfig.PushEndpoint != "" {
+		l.Info("starting continuous profiling", "endpoint", pprofConfig.PushEndpoint, "interval", pprofConfig.PushInterval)
+		servers = append(servers, cliapp.Background("pprof push", l, func(ctx context.Context) error {
+			return oppprof.Push(ctx, l, "op-proposer", pprofConfig.PushEndpoint, pprofConfig.PushInterval)
+		}))
 	}
 
 	metricsCfg := cfg.MetricsConfig
 	if metricsCfg.Enabled {
 		l.Info("starting metrics server", "addr", metricsCfg.ListenAddr, "port", metricsCfg.ListenPort)
-		go func() {
-			if err := m.Serve(ctx, metricsCfg.ListenAddr, metricsCfg.ListenPort); err != nil {
-				l.Error("error starting metrics server", err)
-			}
-		}()
-		m.StartBalanceMetrics(ctx, l, proposerConfig.L1Client, proposerConfig.TxManager.From())
+		servers = append(servers, cliapp.Background("metrics", l, func(ctx context.Context) error {
+			return m.Serve(ctx, metricsCfg.ListenAddr, metricsCfg.ListenPort, metricsCfg.Auth.ServerOptions()...)
+		}), cliapp.Background("balance metrics", l, func(ctx context.Context) error {
+			return m.MonitorBalance(ctx, l, proposerConfig.L1Client, proposerConfig.TxManager.From())
+		}))
+	}
+
+	txMgr := cliapp.Funcs(nil, func(ctx context.Context) error {
+		ctx, cancel := context.WithTimeout(ctx, txmgr.DefaultCloseTimeout)
+		defer cancel()
+		return proposerConfig.TxManager.Close(ctx)
+	})
+	proposer := cliapp.Funcs(func(context.Context) error {
+		l.Info("Starting L2 Output Submitter")
+		return l2OutputSubmitter.Start()
+	}, func(context.Context) error {
+		l2OutputSubmitter.Stop()
+		return nil
+	})
+	services := newServices(txMgr, proposer, servers...)
+	if err := services.Start(context.Background()); err != nil {
+		return fmt.Errorf("error starting services: %w", err)
 	}
+	defer func() {
+		if err := services.Stop(context.Background()); err != nil {
+			l.Error("Error stopping services", "err", err)
+		}
+	}()
+	l.Info("L2 Output Submitter started")
 
 	rpcCfg := cfg.RPCConfig
 	server := oprpc.NewServer(rpcCfg.ListenAddr, rpcCfg.ListenPort, version, oprpc.WithLogger(l))
+	if txMgr, ok := proposerConfig.TxManager.(*txmgr.SimpleTxManager); ok {
+		server.AddAPI(gethrpc.API{
+			Namespace: txmgr.DebugNamespace,
+			Service:   txmgr.NewDebugAPI(txMgr),
+		})
+	}
 	if err := server.Start(); err != nil {
-		cancel()
 		return fmt.Errorf("error starting RPC server: %w", err)
 	}
 
@@ -104,7 +155,6 @@ func Main(version string, cliCtx *cli.Context) error {
 		syscall.SIGQUIT,
 	}...)
 	<-interruptChannel
-	cancel()
 
 	return nil
 }
@@ -0,0 +1,73 @@
+package proposer
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-bindings/predeploys"
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+)
+
+// VerifyResult is the outcome of checking a posted output root against the local L2 chain.
+type VerifyResult struct {
+	OutputIndex   *big.Int
+	L2BlockNumber uint64
+	Posted        eth.Bytes32
+	Local         eth.Bytes32
+}
+
+func (r *VerifyResult) Match() bool {
+	return r.Posted == r.Local
+}
+
+// VerifyOutput looks up the output that the L2OutputOracle holds for l2Block, which is the
+// first output at or after that block, and recomputes its output root from the state of the
+// L2 execution engine at l2Rpc.
+func VerifyOutput(ctx context.Context, l2oo *bindings.L2OutputOracleCaller, l2Rpc *rpc.Client, l2Block uint64) (*VerifyResult, error) {
+	opts := &bind.CallOpts{Context: ctx}
+	index, err := l2oo.GetL2OutputIndexAfter(opts, new(big.Int).SetUint64(l2Block))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find output for L2 block %d: %w", l2Block, err)
+	}
+	proposal, err := l2oo.GetL2Output(opts, index)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch output %d: %w", index, err)
+	}
+
+	header, err := ethclient.NewClient(l2Rpc).HeaderByNumber(ctx, proposal.L2BlockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch L2 block %d: %w", proposal.L2BlockNumber, err)
+	}
+	var proof struct {
+		StorageHash common.Hash `json:"storageHash"`
+	}
+	err = l2Rpc.CallContext(ctx, &proof, "eth_getProof", predeploys.L2ToL1MessagePasserAddr, []common.Hash{}, hexutil.EncodeBig(proposal.L2BlockNumber))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch message passer proof at L2 block %d: %w", proposal.L2BlockNumber, err)
+	}
+
+	local, err := rollup.ComputeL2OutputRoot(&bindings.TypesOutputRootProof{
+		Version:                  [32]byte{},
+		StateRoot:                header.Root,
+		MessagePasserStorageRoot: proof.StorageHash,
+		LatestBlockhash:          header.Hash(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &VerifyResult{
+		OutputIndex:   index,
+		L2BlockNumber: proposal.L2BlockNumber.Uint64(),
+		Posted:        eth.Bytes32(proposal.OutputRoot),
+		Local:         local,
+	}, nil
+}
@@ -0,0 +1,132 @@
+package proposer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-bindings/predeploys"
+)
+
+// stubL2OO serves the output lookups of the L2OutputOracle from a list of outputs.
+type stubL2OO struct {
+	abi     *abi.ABI
+	outputs []bindings.TypesOutputProposal
+}
+
+func (s *stubL2OO) CodeAt(context.Context, common.Address, *big.Int) ([]byte, error) {
+	return []byte{0x01}, nil
+}
+
+func (s *stubL2OO) CallContract(_ context.Context, call ethereum.CallMsg, _ *big.Int) ([]byte, error) {
+	method, err := s.abi.MethodById(call.Data[:4])
+	if err != nil {
+		return nil, err
+	}
+	args, err := method.Inputs.Unpack(call.Data[4:])
+	if err != nil {
+		return nil, err
+	}
+	arg := args[0].(*big.Int)
+	switch method.Name {
+	case "getL2OutputIndexAfter":
+		for i, output := range s.outputs {
+			if output.L2BlockNumber.Cmp(arg) >= 0 {
+				return method.Outputs.Pack(big.NewInt(int64(i)))
+			}
+		}
+		return nil, errors.New("execution reverted: L2OutputOracle: cannot get output for a block that has not been proposed")
+	case "getL2Output":
+		return method.Outputs.Pack(s.outputs[arg.Uint64()])
+	default:
+		return nil, fmt.Errorf("unexpected call to %s", method.Name)
+	}
+}
+
+// fakeL2Eth serves the header and message passer proof of a single L2 block.
+type fakeL2Eth struct {
+	header      *types.Header
+	storageHash common.Hash
+}
+
+func (f *fakeL2Eth) GetBlockByNumber(number hexutil.Big, _ bool) (*types.Header, error) {
+	if (*big.Int)(&number).Cmp(f.header.Number) != 0 {
+		return nil, nil
+	}
+	return f.header, nil
+}
+
+func (f *fakeL2Eth) GetProof(addr common.Address, _ []common.Hash, block hexutil.Big) (map[string]interface{}, error) {
+	if addr != predeploys.L2ToL1MessagePasserAddr {
+		return nil, fmt.Errorf("unexpected proof of %s", addr)
+	}
+	if (*big.Int)(&block).Cmp(f.header.Number) != 0 {
+		return nil, fmt.Errorf("unexpected proof at block %s", (*big.Int)(&block))
+	}
+	return map[string]interface{}{"storageHash": f.storageHash}, nil
+}
+
+func TestVerifyOutput(t *testing.T) {
+	l2ooABI, err := bindings.L2OutputOracleMetaData.GetAbi()
+	require.NoError(t, err)
+
+	l2 := &fakeL2Eth{
+		header: &types.Header{
+			Number:     big.NewInt(20),
+			Root:       common.Hash{0xaa},
+			Difficulty: common.Big0,
+		},
+		storageHash: common.Hash{0xbb},
+	}
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("eth", l2))
+	t.Cleanup(server.Stop)
+	l2Rpc := rpc.DialInProc(server)
+	t.Cleanup(l2Rpc.Close)
+
+	// version 0 output root, committing to the hash of the L2 block
+	var version [32]byte
+	root := crypto.Keccak256Hash(version[:], l2.header.Root[:], l2.storageHash[:], l2.header.Hash().Bytes())
+	outputs := []bindings.TypesOutputProposal{
+		{OutputRoot: [32]byte{0x01}, Timestamp: big.NewInt(100), L2BlockNumber: big.NewInt(10)},
+		{OutputRoot: root, Timestamp: big.NewInt(200), L2BlockNumber: big.NewInt(20)},
+	}
+	l2oo, err := bindings.NewL2OutputOracleCaller(common.Address{0x42}, &stubL2OO{abi: l2ooABI, outputs: outputs})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	t.Run("match", func(t *testing.T) {
+		res, err := VerifyOutput(ctx, l2oo, l2Rpc, 15)
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(1), res.OutputIndex)
+		require.Equal(t, uint64(20), res.L2BlockNumber)
+		require.Equal(t, root, common.Hash(res.Local))
+		require.True(t, res.Match())
+	})
+
+	t.Run("mismatch", func(t *testing.T) {
+		outputs[1].OutputRoot = [32]byte{0x02}
+		defer func() { outputs[1].OutputRoot = root }()
+		res, err := VerifyOutput(ctx, l2oo, l2Rpc, 20)
+		require.NoError(t, err)
+		require.Equal(t, root, common.Hash(res.Local))
+		require.False(t, res.Match())
+	})
+
+	t.Run("no output at or after block", func(t *testing.T) {
+		_, err := VerifyOutput(ctx, l2oo, l2Rpc, 21)
+		require.ErrorContains(t, err, "failed to find output for L2 block 21")
+	})
+}
@@ -0,0 +1,57 @@
+package backoff
+
+import "sync"
+
+// Budget limits the retries of all operations sharing it, so that a failing
+// dependency is not hammered by every caller retrying at once. Every retry
+// costs one token and every success earns back a fraction of one, up to the
+// maximum. Once the tokens are used up, operations fail after their first
+// failed attempt until enough operations succeeded again.
+type Budget struct {
+	mu     sync.Mutex
+	max    float64
+	tokens float64
+	ratio  float64
+}
+
+// NewBudget creates a full Budget of maxRetries retries, which recovers by
+// ratio retries per successful operation.
+func NewBudget(maxRetries int, ratio float64) *Budget {
+	return &Budget{
+		max:    float64(maxRetries),
+		tokens: float64(maxRetries),
+		ratio:  ratio,
+	}
+}
+
+// Remaining returns the number of retries that the budget currently grants.
+func (b *Budget) Remaining() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return int(b.tokens)
+}
+
+func (b *Budget) retry() bool {
+	if b == nil {
+		return true
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+func (b *Budget) success() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.tokens += b.ratio
+	if b.tokens > b.max {
+		b.tokens = b.max
+	}
+}
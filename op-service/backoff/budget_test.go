@@ -0,0 +1,29 @@
+package backoff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBudget(t *testing.T) {
+	b := NewBudget(2, 0.5)
+	require.Equal(t, 2, b.Remaining())
+
+	require.True(t, b.retry())
+	require.True(t, b.retry())
+	require.False(t, b.retry())
+
+	b.success()
+	require.Equal(t, 0, b.Remaining())
+	b.success()
+	require.Equal(t, 1, b.Remaining())
+	for i := 0; i < 10; i++ {
+		b.success()
+	}
+	require.Equal(t, 2, b.Remaining(), "capped at the maximum")
+
+	var unlimited *Budget
+	require.True(t, unlimited.retry())
+	unlimited.success()
+}
@@ -2,6 +2,7 @@ package backoff
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 )
@@ -21,6 +22,36 @@ func (e *ErrFailedPermanently) Error() string {
 	return fmt.Sprintf("operation failed permanently after %d attempts: %v", e.attempts, e.LastErr)
 }
 
+func (e *ErrFailedPermanently) Unwrap() error {
+	return e.LastErr
+}
+
+// PermanentError marks an error that retrying cannot fix, e.g. an invalid
+// request. Do returns the wrapped error immediately instead of retrying.
+type PermanentError struct {
+	Err error
+}
+
+func (e *PermanentError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *PermanentError) Unwrap() error {
+	return e.Err
+}
+
+// Permanent wraps err so that it is not retried. It returns nil if err is nil.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &PermanentError{Err: err}
+}
+
+// ErrBudgetExhausted is wrapped by the error returned by DoWithBudget if an
+// Operation failed, but the retry Budget did not allow another attempt.
+var ErrBudgetExhausted = errors.New("retry budget exhausted")
+
 // Do performs the provided Operation up to maxAttempts times
 // with delays in between each retry according to the provided
 // Strategy.
@@ -29,36 +60,43 @@ func Do(maxAttempts int, strategy Strategy, op Operation) error {
 }
 
 func DoCtx(ctx context.Context, maxAttempts int, strategy Strategy, op Operation) error {
+	return DoWithBudget(ctx, maxAttempts, strategy, nil, op)
+}
+
+// DoWithBudget is like DoCtx, but every retry has to be granted by the budget,
+// which may be shared by many operations. A nil budget grants all retries.
+// Errors wrapped with Permanent are never retried.
+func DoWithBudget(ctx context.Context, maxAttempts int, strategy Strategy, budget *Budget, op Operation) error {
 	if maxAttempts < 1 {
 		return fmt.Errorf("need at least 1 attempt to run op, but have %d max attempts", maxAttempts)
 	}
-	var attempt int
 
-	reattemptCh := make(chan struct{}, 1)
-	doReattempt := func() {
-		reattemptCh <- struct{}{}
-	}
-	doReattempt()
+	for attempt := 1; ; attempt++ {
+		err := op()
+		if err == nil {
+			budget.success()
+			return nil
+		}
+		var permanent *PermanentError
+		if errors.As(err, &permanent) {
+			return permanent.Err
+		}
+		if attempt == maxAttempts {
+			return &ErrFailedPermanently{
+				attempts: maxAttempts,
+				LastErr:  err,
+			}
+		}
+		if !budget.retry() {
+			return fmt.Errorf("%w after %d attempts: %v", ErrBudgetExhausted, attempt, err)
+		}
 
-	for {
+		timer := time.NewTimer(strategy.Duration(attempt - 1))
 		select {
 		case <-ctx.Done():
+			timer.Stop()
 			return ctx.Err()
-		case <-reattemptCh:
-			attempt++
-			err := op()
-			if err == nil {
-				return nil
-			}
-
-			if attempt == maxAttempts {
-				return &ErrFailedPermanently{
-					attempts: maxAttempts,
-					LastErr:  err,
-				}
-			}
-			time.AfterFunc(strategy.Duration(attempt-1), doReattempt)
+		case <-timer.C:
 		}
-
 	}
 }
@@ -1,6 +1,7 @@
 package backoff
 
 import (
+	"context"
 	"errors"
 	"testing"
 	"time"
@@ -32,3 +33,74 @@ func TestDo(t *testing.T) {
 	require.Equal(t, dummyErr, err.(*ErrFailedPermanently).LastErr)
 	require.True(t, time.Since(start) > 20*time.Millisecond)
 }
+
+func TestDoWithBudget(t *testing.T) {
+	dummyErr := errors.New("explode")
+	tests := []struct {
+		name     string
+		results  []error
+		budget   *Budget
+		calls    int
+		checkErr func(t *testing.T, err error)
+	}{
+		{
+			name:    "succeeds after retries",
+			results: []error{dummyErr, dummyErr, nil},
+			calls:   3,
+		},
+		{
+			name:    "gives up after max attempts",
+			results: []error{dummyErr, dummyErr, dummyErr, nil},
+			calls:   3,
+			checkErr: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, dummyErr)
+				require.IsType(t, &ErrFailedPermanently{}, err)
+			},
+		},
+		{
+			name:    "does not retry permanent errors",
+			results: []error{dummyErr, Permanent(dummyErr), nil},
+			calls:   2,
+			checkErr: func(t *testing.T, err error) {
+				require.Equal(t, dummyErr, err)
+			},
+		},
+		{
+			name:    "stops when the budget is exhausted",
+			results: []error{dummyErr, dummyErr, nil},
+			budget:  NewBudget(1, 0.1),
+			calls:   2,
+			checkErr: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, ErrBudgetExhausted)
+			},
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			var calls int
+			err := DoWithBudget(context.Background(), 3, Fixed(time.Millisecond), test.budget, func() error {
+				calls++
+				return test.results[calls-1]
+			})
+			require.Equal(t, test.calls, calls)
+			if test.checkErr == nil {
+				require.NoError(t, err)
+			} else {
+				test.checkErr(t, err)
+			}
+		})
+	}
+}
+
+func TestDoCtxCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var calls int
+	err := DoCtx(ctx, 3, Fixed(time.Hour), func() error {
+		calls++
+		cancel()
+		return errors.New("explode")
+	})
+	require.ErrorIs(t, err, context.Canceled)
+	require.Equal(t, 1, calls)
+}
@@ -0,0 +1,77 @@
+package cliapp
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+)
+
+// CompletionCommand returns a command that prints a shell completion script for
+// the app. The app must have EnableBashCompletion set, since the scripts query
+// the binary itself for the candidates.
+//
+// Usage: source <(op-batcher completion bash)
+func CompletionCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "completion",
+		Usage:     "Prints a shell completion script",
+		ArgsUsage: "bash|zsh",
+		Action: func(ctx *cli.Context) error {
+			var script string
+			switch shell := ctx.Args().First(); shell {
+			case "bash":
+				script = bashCompletion
+			case "zsh":
+				script = zshCompletion
+			default:
+				return fmt.Errorf("unsupported shell %q, must be bash or zsh", shell)
+			}
+			prog := ctx.App.Name
+			script = strings.ReplaceAll(script, "$FUNC", strings.ReplaceAll(prog, "-", "_"))
+			script = strings.ReplaceAll(script, "$PROG", prog)
+			_, err := fmt.Fprint(ctx.App.Writer, script)
+			return err
+		},
+	}
+}
+
+const bashCompletion = `_$FUNC_bash_autocomplete() {
+  if [[ "${COMP_WORDS[0]}" != "source" ]]; then
+    local cur opts
+    COMPREPLY=()
+    cur="${COMP_WORDS[COMP_CWORD]}"
+    if [[ "$cur" == "-"* ]]; then
+      opts=$( ${COMP_WORDS[@]:0:$COMP_CWORD} ${cur} --generate-bash-completion )
+    else
+      opts=$( ${COMP_WORDS[@]:0:$COMP_CWORD} --generate-bash-completion )
+    fi
+    COMPREPLY=( $(compgen -W "${opts}" -- ${cur}) )
+    return 0
+  fi
+}
+
+complete -o bashdefault -o default -o nospace -F _$FUNC_bash_autocomplete $PROG
+`
+
+const zshCompletion = `#compdef $PROG
+
+_$FUNC_zsh_autocomplete() {
+  local -a opts
+  local cur
+  cur=${words[-1]}
+  if [[ "$cur" == "-"* ]]; then
+    opts=("${(@f)$(${words[@]:0:#words[@]-1} ${cur} --generate-bash-completion)}")
+  else
+    opts=("${(@f)$(${words[@]:0:#words[@]-1} --generate-bash-completion)}")
+  fi
+
+  if [[ "${opts[1]}" != "" ]]; then
+    _describe 'values' opts
+  else
+    _files
+  fi
+}
+
+compdef _$FUNC_zsh_autocomplete $PROG
+`
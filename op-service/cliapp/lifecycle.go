@@ -0,0 +1,139 @@
+package cliapp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/hashicorp/go-multierror"
+)
+
+// Lifecycle is a component of a service that runs in the background between
+// Start and Stop. The contexts only bound starting and stopping themselves.
+type Lifecycle interface {
+	Start(ctx context.Context) error
+	Stop(ctx context.Context) error
+}
+
+// Background returns a Lifecycle that runs fn in a goroutine. Stop cancels the
+// context passed to fn and waits for fn to return. An error of fn is logged as
+// soon as it occurs, and returned by Stop.
+func Background(name string, l log.Logger, fn func(ctx context.Context) error) Lifecycle {
+	return &background{name: name, log: l, fn: fn}
+}
+
+type background struct {
+	name string
+	log  log.Logger
+	fn   func(ctx context.Context) error
+
+	cancel context.CancelFunc
+	done   chan struct{}
+	err    error
+}
+
+func (b *background) Start(_ context.Context) error {
+	if b.done != nil {
+		return fmt.Errorf("%s already started", b.name)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	b.cancel = cancel
+	b.done = make(chan struct{})
+	go func() {
+		defer close(b.done)
+		if err := b.fn(ctx); err != nil && !errors.Is(err, context.Canceled) {
+			b.log.Error("Background service failed", "service", b.name, "err", err)
+			b.err = fmt.Errorf("%s: %w", b.name, err)
+		}
+	}()
+	return nil
+}
+
+func (b *background) Stop(ctx context.Context) error {
+	if b.done == nil {
+		return nil
+	}
+	b.cancel()
+	select {
+	case <-b.done:
+		return b.err
+	case <-ctx.Done():
+		return fmt.Errorf("stopping %s: %w", b.name, ctx.Err())
+	}
+}
+
+// Funcs returns a Lifecycle that calls start and stop, e.g. to adapt a component
+// with another interface. A nil function does nothing.
+func Funcs(start, stop func(ctx context.Context) error) Lifecycle {
+	return &funcs{start: start, stop: stop}
+}
+
+type funcs struct {
+	start, stop func(ctx context.Context) error
+}
+
+func (f *funcs) Start(ctx context.Context) error {
+	if f.start == nil {
+		return nil
+	}
+	return f.start(ctx)
+}
+
+func (f *funcs) Stop(ctx context.Context) error {
+	if f.stop == nil {
+		return nil
+	}
+	return f.stop(ctx)
+}
+
+// Group composes Lifecycles. They are started in the order they were added, and
+// stopped in reverse order, so that components can depend on earlier ones.
+type Group struct {
+	mu      sync.Mutex
+	members []Lifecycle
+	started int
+}
+
+// Add adds a Lifecycle to the group. It must be called before Start.
+func (g *Group) Add(l Lifecycle) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.members = append(g.members, l)
+}
+
+// Start starts all members. If one fails to start, the members that were
+// already started are stopped again, and the start error is returned.
+func (g *Group) Start(ctx context.Context) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for _, m := range g.members[g.started:] {
+		if err := m.Start(ctx); err != nil {
+			if stopErr := g.stop(ctx); stopErr != nil {
+				return multierror.Append(err, stopErr)
+			}
+			return err
+		}
+		g.started++
+	}
+	return nil
+}
+
+// Stop stops all started members in reverse order. All members are stopped,
+// even if some fail to, and all errors are returned.
+func (g *Group) Stop(ctx context.Context) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.stop(ctx)
+}
+
+func (g *Group) stop(ctx context.Context) error {
+	var result *multierror.Error
+	for ; g.started > 0; g.started-- {
+		if err := g.members[g.started-1].Stop(ctx); err != nil {
+			result = multierror.Append(result, err)
+		}
+	}
+	return result.ErrorOrNil()
+}
@@ -0,0 +1,101 @@
+package cliapp
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+)
+
+type recordingLifecycle struct {
+	name     string
+	events   *[]string
+	startErr error
+	stopErr  error
+}
+
+func (r *recordingLifecycle) Start(context.Context) error {
+	*r.events = append(*r.events, "start "+r.name)
+	return r.startErr
+}
+
+func (r *recordingLifecycle) Stop(context.Context) error {
+	*r.events = append(*r.events, "stop "+r.name)
+	return r.stopErr
+}
+
+func TestGroup(t *testing.T) {
+	ctx := context.Background()
+	var events []string
+	var g Group
+	g.Add(&recordingLifecycle{name: "a", events: &events})
+	g.Add(&recordingLifecycle{name: "b", events: &events, stopErr: errors.New("b failed")})
+	g.Add(&recordingLifecycle{name: "c", events: &events})
+
+	require.NoError(t, g.Start(ctx))
+	err := g.Stop(ctx)
+	require.ErrorContains(t, err, "b failed")
+	require.Equal(t, []string{"start a", "start b", "start c", "stop c", "stop b", "stop a"}, events)
+
+	events = nil
+	require.NoError(t, g.Stop(ctx), "stopping twice is a no-op")
+	require.Empty(t, events)
+}
+
+func TestGroupStartFailure(t *testing.T) {
+	ctx := context.Background()
+	var events []string
+	startErr := errors.New("b failed")
+	var g Group
+	g.Add(&recordingLifecycle{name: "a", events: &events})
+	g.Add(&recordingLifecycle{name: "b", events: &events, startErr: startErr})
+	g.Add(&recordingLifecycle{name: "c", events: &events})
+
+	require.ErrorIs(t, g.Start(ctx), startErr)
+	require.Equal(t, []string{"start a", "start b", "stop a"}, events)
+}
+
+func TestBackground(t *testing.T) {
+	ctx := context.Background()
+	lgr := testlog.Logger(t, log.LvlCrit)
+
+	started := make(chan struct{})
+	b := Background("waits", lgr, func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	require.NoError(t, b.Start(ctx))
+	<-started
+	require.NoError(t, b.Stop(ctx), "cancellation is not an error")
+
+	fnErr := errors.New("listen failed")
+	b = Background("fails", lgr, func(ctx context.Context) error {
+		return fnErr
+	})
+	require.NoError(t, b.Start(ctx))
+	require.ErrorIs(t, b.Stop(ctx), fnErr)
+}
+
+func TestFuncs(t *testing.T) {
+	ctx := context.Background()
+	var events []string
+	stopErr := errors.New("stop failed")
+	f := Funcs(func(context.Context) error {
+		events = append(events, "start")
+		return nil
+	}, func(context.Context) error {
+		events = append(events, "stop")
+		return stopErr
+	})
+	require.NoError(t, f.Start(ctx))
+	require.ErrorIs(t, f.Stop(ctx), stopErr)
+	require.Equal(t, []string{"start", "stop"}, events)
+
+	require.NoError(t, Funcs(nil, nil).Start(ctx))
+	require.NoError(t, Funcs(nil, nil).Stop(ctx))
+}
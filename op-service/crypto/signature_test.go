@@ -0,0 +1,61 @@
+package crypto
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// benchTx returns an unsigned transaction with dataSize bytes of calldata, like
+// a batcher transaction.
+func benchTx(dataSize int) *types.Transaction {
+	to := common.Address{0xff}
+	return types.NewTx(&types.DynamicFeeTx{
+		ChainID:   big.NewInt(1),
+		Nonce:     42,
+		GasTipCap: big.NewInt(1_000_000_000),
+		GasFeeCap: big.NewInt(30_000_000_000),
+		Gas:       100_000,
+		To:        &to,
+		Data:      make([]byte, dataSize),
+	})
+}
+
+func BenchmarkPrivateKeySignerFn(b *testing.B) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		b.Fatal(err)
+	}
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	signer := PrivateKeySignerFn(key, big.NewInt(1))
+	tx := benchTx(1024)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := signer(from, tx); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSignedTxMarshalBinary(b *testing.B) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		b.Fatal(err)
+	}
+	tx, err := PrivateKeySignerFn(key, big.NewInt(1))(crypto.PubkeyToAddress(key.PublicKey), benchTx(1024))
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(tx.Size()))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := tx.MarshalBinary(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
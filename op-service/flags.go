@@ -0,0 +1,16 @@
+package op_service
+
+// Flag categories, used to group related flags in the help output of the
+// services. Categories are shared so that the same group of flags is labeled
+// the same across binaries.
+const (
+	L1Category      = "L1"
+	L2Category      = "L2"
+	SigningCategory = "SIGNING"
+	TxMgrCategory   = "TXMGR TUNING"
+	MetricsCategory = "METRICS"
+	LoggingCategory = "LOGGING"
+	RPCCategory     = "RPC"
+	PprofCategory   = "PPROF"
+	TLSCategory     = "TLS"
+)
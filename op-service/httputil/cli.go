@@ -0,0 +1,99 @@
+package httputil
+
+import (
+	"errors"
+
+	"github.com/urfave/cli/v2"
+
+	opservice "github.com/ethereum-optimism/optimism/op-service"
+)
+
+const (
+	BasicAuthUserFlagSuffix     = "basic-auth.user"
+	BasicAuthPasswordFlagSuffix = "basic-auth.password"
+	TLSCertFlagSuffix           = "tls.cert"
+	TLSKeyFlagSuffix            = "tls.key"
+	TLSClientCAFlagSuffix       = "tls.client-ca"
+)
+
+// AuthCLIFlags returns the access control flags of an HTTP server. Flag names
+// are prefixed with flagPrefix (e.g. "metrics") and env vars with envPrefix.
+func AuthCLIFlags(flagPrefix, envPrefix, category string) []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:     flagPrefix + "." + BasicAuthUserFlagSuffix,
+			Usage:    "Require HTTP basic auth with this user name",
+			EnvVars:  []string{opservice.PrefixEnvVar(envPrefix, "BASIC_AUTH_USER")},
+			Category: category,
+		},
+		&cli.StringFlag{
+			Name:     flagPrefix + "." + BasicAuthPasswordFlagSuffix,
+			Usage:    "Require HTTP basic auth with this password",
+			EnvVars:  []string{opservice.PrefixEnvVar(envPrefix, "BASIC_AUTH_PASSWORD")},
+			Category: category,
+		},
+		&cli.StringFlag{
+			Name:     flagPrefix + "." + TLSCertFlagSuffix,
+			Usage:    "Serve over TLS using this certificate",
+			EnvVars:  []string{opservice.PrefixEnvVar(envPrefix, "TLS_CERT")},
+			Category: category,
+		},
+		&cli.StringFlag{
+			Name:     flagPrefix + "." + TLSKeyFlagSuffix,
+			Usage:    "Private key of the TLS certificate",
+			EnvVars:  []string{opservice.PrefixEnvVar(envPrefix, "TLS_KEY")},
+			Category: category,
+		},
+		&cli.StringFlag{
+			Name:     flagPrefix + "." + TLSClientCAFlagSuffix,
+			Usage:    "Require client certificates signed by this CA (mTLS)",
+			EnvVars:  []string{opservice.PrefixEnvVar(envPrefix, "TLS_CLIENT_CA")},
+			Category: category,
+		},
+	}
+}
+
+// AuthCLIConfig holds the optional access controls of an HTTP server.
+// The zero value serves plain HTTP without authentication.
+type AuthCLIConfig struct {
+	BasicAuthUser     string
+	BasicAuthPassword string
+	TLSCert           string
+	TLSKey            string
+	TLSClientCA       string
+}
+
+func (c AuthCLIConfig) Check() error {
+	if (c.BasicAuthUser == "") != (c.BasicAuthPassword == "") {
+		return errors.New("basic auth user and password must be set together")
+	}
+	if (c.TLSCert == "") != (c.TLSKey == "") {
+		return errors.New("tls cert and key must be set together")
+	}
+	if c.TLSClientCA != "" && c.TLSCert == "" {
+		return errors.New("tls client ca requires a tls cert and key")
+	}
+	return nil
+}
+
+// ServerOptions returns the server options that enforce the configured access controls.
+func (c AuthCLIConfig) ServerOptions() []ServerOption {
+	var opts []ServerOption
+	if c.BasicAuthUser != "" {
+		opts = append(opts, WithBasicAuth(c.BasicAuthUser, c.BasicAuthPassword))
+	}
+	if c.TLSCert != "" {
+		opts = append(opts, WithTLS(c.TLSCert, c.TLSKey, c.TLSClientCA))
+	}
+	return opts
+}
+
+func ReadAuthCLIConfig(ctx *cli.Context, flagPrefix string) AuthCLIConfig {
+	return AuthCLIConfig{
+		BasicAuthUser:     ctx.String(flagPrefix + "." + BasicAuthUserFlagSuffix),
+		BasicAuthPassword: ctx.String(flagPrefix + "." + BasicAuthPasswordFlagSuffix),
+		TLSCert:           ctx.String(flagPrefix + "." + TLSCertFlagSuffix),
+		TLSKey:            ctx.String(flagPrefix + "." + TLSKeyFlagSuffix),
+		TLSClientCA:       ctx.String(flagPrefix + "." + TLSClientCAFlagSuffix),
+	}
+}
@@ -0,0 +1,75 @@
+package httputil
+
+import (
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+)
+
+// ServerOption modifies an http.Server before it starts serving.
+type ServerOption func(srv *http.Server) error
+
+// ApplyOptions applies all opts to srv, in order.
+func ApplyOptions(srv *http.Server, opts ...ServerOption) error {
+	for _, opt := range opts {
+		if err := opt(srv); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WithBasicAuth requires every request to carry the given basic auth credentials.
+func WithBasicAuth(user, password string) ServerOption {
+	return func(srv *http.Server) error {
+		next := srv.Handler
+		if next == nil {
+			next = http.DefaultServeMux
+		}
+		srv.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			u, p, ok := r.BasicAuth()
+			if !ok ||
+				subtle.ConstantTimeCompare([]byte(u), []byte(user)) != 1 ||
+				subtle.ConstantTimeCompare([]byte(p), []byte(password)) != 1 {
+				w.Header().Set("WWW-Authenticate", `Basic realm="restricted", charset="UTF-8"`)
+				http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+		return nil
+	}
+}
+
+// WithTLS serves over TLS using the given certificate and key. If clientCAFile
+// is not empty, clients must present a certificate signed by one of its CAs.
+func WithTLS(certFile, keyFile, clientCAFile string) ServerOption {
+	return func(srv *http.Server) error {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return fmt.Errorf("failed to load tls key pair: %w", err)
+		}
+		cfg := &tls.Config{
+			Certificates: []tls.Certificate{cert},
+			MinVersion:   tls.VersionTLS12,
+		}
+		if clientCAFile != "" {
+			caPEM, err := os.ReadFile(clientCAFile)
+			if err != nil {
+				return fmt.Errorf("failed to read client ca: %w", err)
+			}
+			pool := x509.NewCertPool()
+			if !pool.AppendCertsFromPEM(caPEM) {
+				return errors.New("no certificates found in client ca")
+			}
+			cfg.ClientCAs = pool
+			cfg.ClientAuth = tls.RequireAndVerifyClientCert
+		}
+		srv.TLSConfig = cfg
+		return nil
+	}
+}
@@ -0,0 +1,52 @@
+package httputil
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithBasicAuth(t *testing.T) {
+	srv := &http.Server{
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}),
+	}
+	require.NoError(t, ApplyOptions(srv, WithBasicAuth("user", "pass")))
+
+	tests := []struct {
+		name     string
+		user     string
+		password string
+		setAuth  bool
+		status   int
+	}{
+		{"no credentials", "", "", false, http.StatusUnauthorized},
+		{"wrong user", "other", "pass", true, http.StatusUnauthorized},
+		{"wrong password", "user", "other", true, http.StatusUnauthorized},
+		{"valid", "user", "pass", true, http.StatusOK},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if test.setAuth {
+				req.SetBasicAuth(test.user, test.password)
+			}
+			rec := httptest.NewRecorder()
+			srv.Handler.ServeHTTP(rec, req)
+			require.Equal(t, test.status, rec.Code)
+		})
+	}
+}
+
+func TestAuthCLIConfigCheck(t *testing.T) {
+	require.NoError(t, AuthCLIConfig{}.Check())
+	require.NoError(t, AuthCLIConfig{BasicAuthUser: "u", BasicAuthPassword: "p"}.Check())
+	require.NoError(t, AuthCLIConfig{TLSCert: "c", TLSKey: "k", TLSClientCA: "ca"}.Check())
+	require.Error(t, AuthCLIConfig{BasicAuthUser: "u"}.Check())
+	require.Error(t, AuthCLIConfig{TLSCert: "c"}.Check())
+	require.Error(t, AuthCLIConfig{TLSClientCA: "ca"}.Check())
+}
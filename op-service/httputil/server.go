@@ -8,10 +8,16 @@ import (
 	"time"
 )
 
+// ListenAndServeContext serves until ctx is done. If the server has a TLS
+// config, e.g. set by WithTLS, it serves over TLS.
 func ListenAndServeContext(ctx context.Context, server *http.Server) error {
 	errCh := make(chan error, 1)
 	go func() {
-		errCh <- server.ListenAndServe()
+		if server.TLSConfig != nil {
+			errCh <- server.ListenAndServeTLS("", "")
+		} else {
+			errCh <- server.ListenAndServe()
+		}
 	}()
 
 	// verify that the server comes up
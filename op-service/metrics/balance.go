@@ -27,33 +27,39 @@ func weiToEther(wei *big.Int) float64 {
 // Cancel the supplied context to shut down the go routine
 func LaunchBalanceMetrics(ctx context.Context, log log.Logger, r *prometheus.Registry, ns string, client *ethclient.Client, account common.Address) {
 	go func() {
-		balanceGuage := promauto.With(r).NewGauge(prometheus.GaugeOpts{
-			Namespace: ns,
-			Name:      "balance",
-			Help:      "balance (in ether) of account " + account.String(),
-		})
+		_ = MonitorBalance(ctx, log, r, ns, client, account)
+	}()
+}
+
+// MonitorBalance periodically records the balance of the supplied account, like
+// LaunchBalanceMetrics, but blocks until the context is canceled, and returns its
+// error.
+func MonitorBalance(ctx context.Context, log log.Logger, r *prometheus.Registry, ns string, client *ethclient.Client, account common.Address) error {
+	balanceGuage := promauto.With(r).NewGauge(prometheus.GaugeOpts{
+		Namespace: ns,
+		Name:      "balance",
+		Help:      "balance (in ether) of account " + account.String(),
+	})
 
-		ticker := time.NewTicker(10 * time.Second)
-		defer ticker.Stop()
+	ticker := time.NewTicker(10 * time.Second)
+	defer ticker.Stop()
 
-		for {
-			select {
-			case <-ticker.C:
-				ctx, cancel := context.WithTimeout(ctx, 1*time.Minute)
-				bigBal, err := client.BalanceAt(ctx, account, nil)
-				if err != nil {
-					log.Warn("failed to get balance of account", "err", err, "address", account)
-					cancel()
-					continue
-				}
-				bal := weiToEther(bigBal)
-				balanceGuage.Set(bal)
+	for {
+		select {
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(ctx, 1*time.Minute)
+			bigBal, err := client.BalanceAt(ctx, account, nil)
+			if err != nil {
+				log.Warn("failed to get balance of account", "err", err, "address", account)
 				cancel()
-			case <-ctx.Done():
-				log.Info("balance metrics shutting down")
-				return
+				continue
 			}
+			bal := weiToEther(bigBal)
+			balanceGuage.Set(bal)
+			cancel()
+		case <-ctx.Done():
+			log.Info("balance metrics shutting down")
+			return ctx.Err()
 		}
-
-	}()
+	}
 }
@@ -5,42 +5,59 @@ import (
 	"math"
 
 	opservice "github.com/ethereum-optimism/optimism/op-service"
+	"github.com/ethereum-optimism/optimism/op-service/httputil"
 
-	"github.com/urfave/cli"
+	"github.com/urfave/cli/v2"
 )
 
 const (
-	EnabledFlagName    = "metrics.enabled"
-	ListenAddrFlagName = "metrics.addr"
-	PortFlagName       = "metrics.port"
+	EnabledFlagName      = "metrics.enabled"
+	ListenAddrFlagName   = "metrics.addr"
+	PortFlagName         = "metrics.port"
+	CountersFileFlagName = "metrics.counters-file"
+
+	authFlagPrefix = "metrics"
 )
 
 func CLIFlags(envPrefix string) []cli.Flag {
-	return []cli.Flag{
-		cli.BoolFlag{
-			Name:   EnabledFlagName,
-			Usage:  "Enable the metrics server",
-			EnvVar: opservice.PrefixEnvVar(envPrefix, "METRICS_ENABLED"),
+	flags := []cli.Flag{
+		&cli.BoolFlag{
+			Name:     EnabledFlagName,
+			Usage:    "Enable the metrics server",
+			EnvVars:  []string{opservice.PrefixEnvVar(envPrefix, "METRICS_ENABLED")},
+			Category: opservice.MetricsCategory,
+		},
+		&cli.StringFlag{
+			Name:     ListenAddrFlagName,
+			Usage:    "Metrics listening address",
+			Value:    "0.0.0.0",
+			EnvVars:  []string{opservice.PrefixEnvVar(envPrefix, "METRICS_ADDR")},
+			Category: opservice.MetricsCategory,
 		},
-		cli.StringFlag{
-			Name:   ListenAddrFlagName,
-			Usage:  "Metrics listening address",
-			Value:  "0.0.0.0",
-			EnvVar: opservice.PrefixEnvVar(envPrefix, "METRICS_ADDR"),
+		&cli.IntFlag{
+			Name:     PortFlagName,
+			Usage:    "Metrics listening port",
+			Value:    7300,
+			EnvVars:  []string{opservice.PrefixEnvVar(envPrefix, "METRICS_PORT")},
+			Category: opservice.MetricsCategory,
 		},
-		cli.IntFlag{
-			Name:   PortFlagName,
-			Usage:  "Metrics listening port",
-			Value:  7300,
-			EnvVar: opservice.PrefixEnvVar(envPrefix, "METRICS_PORT"),
+		&cli.StringFlag{
+			Name:     CountersFileFlagName,
+			Usage:    "File to persist business counters, like total fees spent, across restarts. Disabled if empty",
+			EnvVars:  []string{opservice.PrefixEnvVar(envPrefix, "METRICS_COUNTERS_FILE")},
+			Category: opservice.MetricsCategory,
 		},
 	}
+	return append(flags, httputil.AuthCLIFlags(authFlagPrefix, opservice.PrefixEnvVar(envPrefix, "METRICS"), opservice.MetricsCategory)...)
 }
 
 type CLIConfig struct {
 	Enabled    bool
 	ListenAddr string
 	ListenPort int
+	Auth       httputil.AuthCLIConfig
+
+	CountersFile string
 }
 
 func (m CLIConfig) Check() error {
@@ -52,21 +69,16 @@ func (m CLIConfig) Check() error {
 		return errors.New("invalid metrics port")
 	}
 
-	return nil
+	return m.Auth.Check()
 }
 
 func ReadCLIConfig(ctx *cli.Context) CLIConfig {
-	return CLIConfig{
-		Enabled:    ctx.GlobalBool(EnabledFlagName),
-		ListenAddr: ctx.GlobalString(ListenAddrFlagName),
-		ListenPort: ctx.GlobalInt(PortFlagName),
-	}
-}
-
-func ReadLocalCLIConfig(ctx *cli.Context) CLIConfig {
 	return CLIConfig{
 		Enabled:    ctx.Bool(EnabledFlagName),
 		ListenAddr: ctx.String(ListenAddrFlagName),
 		ListenPort: ctx.Int(PortFlagName),
+		Auth:       httputil.ReadAuthCLIConfig(ctx, authFlagPrefix),
+
+		CountersFile: ctx.String(CountersFileFlagName),
 	}
 }
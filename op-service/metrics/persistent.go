@@ -0,0 +1,120 @@
+package metrics
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// CounterFlushInterval is how often a started CounterStore writes its values to disk.
+const CounterFlushInterval = 30 * time.Second
+
+// CounterStore persists the values of counters in a small local JSON file, so that
+// counters operators rely on, like the total fees spent, survive process restarts
+// instead of being reset to zero.
+type CounterStore struct {
+	mu     sync.Mutex
+	path   string
+	values map[string]float64
+}
+
+// LoadCounterStore reads the counter values from the file at path. A missing file
+// results in an empty store.
+func LoadCounterStore(path string) (*CounterStore, error) {
+	s := &CounterStore{
+		path:   path,
+		values: make(map[string]float64),
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read counters file: %w", err)
+	}
+	if err := json.Unmarshal(data, &s.values); err != nil {
+		return nil, fmt.Errorf("failed to decode counters file %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// Counter returns a counter that starts at the value persisted under name and
+// persists all increments of c under that name.
+func (s *CounterStore) Counter(name string, c prometheus.Counter) prometheus.Counter {
+	s.mu.Lock()
+	c.Add(s.values[name])
+	s.mu.Unlock()
+	return &persistentCounter{Counter: c, name: name, store: s}
+}
+
+func (s *CounterStore) add(name string, v float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.values[name] += v
+}
+
+// Flush writes the current counter values to the file. The file is replaced
+// atomically, so a crash cannot leave a partially written file behind.
+func (s *CounterStore) Flush() error {
+	s.mu.Lock()
+	data, err := json.MarshalIndent(s.values, "", "  ")
+	s.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write counters file: %w", err)
+	}
+	return os.Rename(tmp, s.path)
+}
+
+// Start flushes the store every interval in the background. The returned function
+// stops the background flushing and flushes one final time.
+func (s *CounterStore) Start(l log.Logger, interval time.Duration) (stop func()) {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if err := s.Flush(); err != nil {
+					l.Warn("failed to persist counters", "err", err)
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+	return func() {
+		close(done)
+		<-stopped
+		if err := s.Flush(); err != nil {
+			l.Error("failed to persist counters", "err", err)
+		}
+	}
+}
+
+type persistentCounter struct {
+	prometheus.Counter
+	name  string
+	store *CounterStore
+}
+
+func (c *persistentCounter) Inc() {
+	c.Add(1)
+}
+
+func (c *persistentCounter) Add(v float64) {
+	// the wrapped counter panics on negative values, before they reach the store
+	c.Counter.Add(v)
+	c.store.add(c.name, v)
+}
@@ -0,0 +1,33 @@
+package metrics
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCounterStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "counters.json")
+
+	store, err := LoadCounterStore(path)
+	require.NoError(t, err)
+	c := store.Counter("fees", prometheus.NewCounter(prometheus.CounterOpts{Name: "fees"}))
+	c.Add(2.5)
+	c.Inc()
+	require.Equal(t, 3.5, testutil.ToFloat64(c))
+	require.NoError(t, store.Flush())
+
+	// simulate a restart
+	store, err = LoadCounterStore(path)
+	require.NoError(t, err)
+	c = store.Counter("fees", prometheus.NewCounter(prometheus.CounterOpts{Name: "fees"}))
+	require.Equal(t, 3.5, testutil.ToFloat64(c))
+	c.Add(1)
+	require.Equal(t, 4.5, testutil.ToFloat64(c))
+
+	other := store.Counter("bytes", prometheus.NewCounter(prometheus.CounterOpts{Name: "bytes"}))
+	require.Equal(t, 0.0, testutil.ToFloat64(other))
+}
@@ -11,7 +11,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
-func ListenAndServe(ctx context.Context, r *prometheus.Registry, hostname string, port int) error {
+func ListenAndServe(ctx context.Context, r *prometheus.Registry, hostname string, port int, opts ...httputil.ServerOption) error {
 	addr := net.JoinHostPort(hostname, strconv.Itoa(port))
 	server := &http.Server{
 		Addr: addr,
@@ -19,5 +19,8 @@ func ListenAndServe(ctx context.Context, r *prometheus.Registry, hostname string
 			r, promhttp.HandlerFor(r, promhttp.HandlerOpts{}),
 		),
 	}
+	if err := httputil.ApplyOptions(server, opts...); err != nil {
+		return err
+	}
 	return httputil.ListenAndServeContext(ctx, server)
 }
@@ -2,47 +2,84 @@ package pprof
 
 import (
 	"errors"
+	"fmt"
 	"math"
+	"time"
 
 	opservice "github.com/ethereum-optimism/optimism/op-service"
-	"github.com/urfave/cli"
+	"github.com/ethereum-optimism/optimism/op-service/httputil"
+	"github.com/urfave/cli/v2"
 )
 
 const (
-	EnabledFlagName    = "pprof.enabled"
-	ListenAddrFlagName = "pprof.addr"
-	PortFlagName       = "pprof.port"
+	EnabledFlagName      = "pprof.enabled"
+	ListenAddrFlagName   = "pprof.addr"
+	PortFlagName         = "pprof.port"
+	PushEndpointFlagName = "pprof.push.endpoint"
+	PushIntervalFlagName = "pprof.push.interval"
+
+	authFlagPrefix = "pprof"
 )
 
 func CLIFlags(envPrefix string) []cli.Flag {
-	return []cli.Flag{
-		cli.BoolFlag{
-			Name:   EnabledFlagName,
-			Usage:  "Enable the pprof server",
-			EnvVar: opservice.PrefixEnvVar(envPrefix, "PPROF_ENABLED"),
+	flags := []cli.Flag{
+		&cli.BoolFlag{
+			Name:     EnabledFlagName,
+			Usage:    "Enable the pprof server",
+			EnvVars:  []string{opservice.PrefixEnvVar(envPrefix, "PPROF_ENABLED")},
+			Category: opservice.PprofCategory,
+		},
+		&cli.StringFlag{
+			Name:     ListenAddrFlagName,
+			Usage:    "pprof listening address. Set to 127.0.0.1 to keep pprof local while metrics are exposed",
+			Value:    "0.0.0.0",
+			EnvVars:  []string{opservice.PrefixEnvVar(envPrefix, "PPROF_ADDR")},
+			Category: opservice.PprofCategory,
 		},
-		cli.StringFlag{
-			Name:   ListenAddrFlagName,
-			Usage:  "pprof listening address",
-			Value:  "0.0.0.0",
-			EnvVar: opservice.PrefixEnvVar(envPrefix, "PPROF_ADDR"),
+		&cli.IntFlag{
+			Name:     PortFlagName,
+			Usage:    "pprof listening port",
+			Value:    6060,
+			EnvVars:  []string{opservice.PrefixEnvVar(envPrefix, "PPROF_PORT")},
+			Category: opservice.PprofCategory,
 		},
-		cli.IntFlag{
-			Name:   PortFlagName,
-			Usage:  "pprof listening port",
-			Value:  6060,
-			EnvVar: opservice.PrefixEnvVar(envPrefix, "PPROF_PORT"),
+		&cli.StringFlag{
+			Name:     PushEndpointFlagName,
+			Usage:    "Continuously profile and push the profiles to this collector endpoint (Pyroscope ingest API). Works independently of the pprof server",
+			EnvVars:  []string{opservice.PrefixEnvVar(envPrefix, "PPROF_PUSH_ENDPOINT")},
+			Category: opservice.PprofCategory,
+		},
+		&cli.DurationFlag{
+			Name:     PushIntervalFlagName,
+			Usage:    "Duration of each pushed profile",
+			Value:    time.Minute,
+			EnvVars:  []string{opservice.PrefixEnvVar(envPrefix, "PPROF_PUSH_INTERVAL")},
+			Category: opservice.PprofCategory,
 		},
 	}
+	return append(flags, httputil.AuthCLIFlags(authFlagPrefix, opservice.PrefixEnvVar(envPrefix, "PPROF"), opservice.PprofCategory)...)
 }
 
 type CLIConfig struct {
 	Enabled    bool
 	ListenAddr string
 	ListenPort int
+	Auth       httputil.AuthCLIConfig
+
+	PushEndpoint string
+	PushInterval time.Duration
 }
 
 func (m CLIConfig) Check() error {
+	if m.PushEndpoint != "" {
+		if err := opservice.CheckURL(m.PushEndpoint, "http", "https"); err != nil {
+			return fmt.Errorf("invalid pprof push endpoint: %w", err)
+		}
+		if m.PushInterval <= 0 {
+			return errors.New("pprof push interval must be positive")
+		}
+	}
+
 	if !m.Enabled {
 		return nil
 	}
@@ -51,13 +88,17 @@ func (m CLIConfig) Check() error {
 		return errors.New("invalid pprof port")
 	}
 
-	return nil
+	return m.Auth.Check()
 }
 
 func ReadCLIConfig(ctx *cli.Context) CLIConfig {
 	return CLIConfig{
-		Enabled:    ctx.GlobalBool(EnabledFlagName),
-		ListenAddr: ctx.GlobalString(ListenAddrFlagName),
-		ListenPort: ctx.GlobalInt(PortFlagName),
+		Enabled:    ctx.Bool(EnabledFlagName),
+		ListenAddr: ctx.String(ListenAddrFlagName),
+		ListenPort: ctx.Int(PortFlagName),
+		Auth:       httputil.ReadAuthCLIConfig(ctx, authFlagPrefix),
+
+		PushEndpoint: ctx.String(PushEndpointFlagName),
+		PushInterval: ctx.Duration(PushIntervalFlagName),
 	}
 }
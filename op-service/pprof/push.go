@@ -0,0 +1,87 @@
+package pprof
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	runtimepprof "runtime/pprof"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// Push continuously profiles the process and uploads a CPU profile covering
+// each interval, plus a heap snapshot at the end of it, to the given endpoint.
+// Profiles are sent in pprof format to the Pyroscope style ingest API at
+// <endpoint>/ingest. Push blocks until the context is canceled. Upload errors
+// are logged and do not stop profiling.
+func Push(ctx context.Context, lgr log.Logger, appName string, endpoint string, interval time.Duration) error {
+	ingestURL, err := url.JoinPath(endpoint, "ingest")
+	if err != nil {
+		return fmt.Errorf("invalid profile push endpoint: %w", err)
+	}
+	client := &http.Client{
+		Timeout: 30 * time.Second,
+	}
+
+	upload := func(profile string, from, until time.Time, data []byte) {
+		q := url.Values{}
+		q.Set("name", appName+"."+profile)
+		q.Set("from", strconv.FormatInt(from.Unix(), 10))
+		q.Set("until", strconv.FormatInt(until.Unix(), 10))
+		q.Set("format", "pprof")
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, ingestURL+"?"+q.Encode(), bytes.NewReader(data))
+		if err != nil {
+			lgr.Error("error creating profile upload request", "err", err)
+			return
+		}
+		req.Header.Set("Content-Type", "application/octet-stream")
+		res, err := client.Do(req)
+		if err != nil {
+			lgr.Warn("error uploading profile", "profile", profile, "err", err)
+			return
+		}
+		res.Body.Close()
+		if res.StatusCode < 200 || res.StatusCode > 299 {
+			lgr.Warn("profile collector returned non-2xx status code", "profile", profile, "status", res.StatusCode)
+		}
+	}
+
+	timer := time.NewTimer(interval)
+	defer timer.Stop()
+	for {
+		from := time.Now()
+		var cpu bytes.Buffer
+		// Only one CPU profile can be recorded at a time, so this fails while
+		// someone is using the /debug/pprof/profile endpoint.
+		cpuErr := runtimepprof.StartCPUProfile(&cpu)
+		if cpuErr != nil {
+			lgr.Warn("skipping cpu profile upload", "err", cpuErr)
+		}
+
+		select {
+		case <-ctx.Done():
+			if cpuErr == nil {
+				runtimepprof.StopCPUProfile()
+			}
+			return nil
+		case <-timer.C:
+			timer.Reset(interval)
+		}
+
+		until := time.Now()
+		if cpuErr == nil {
+			runtimepprof.StopCPUProfile()
+			upload("cpu", from, until, cpu.Bytes())
+		}
+		var heap bytes.Buffer
+		if err := runtimepprof.Lookup("heap").WriteTo(&heap, 0); err != nil {
+			lgr.Warn("error writing heap profile", "err", err)
+			continue
+		}
+		upload("heap", from, until, heap.Bytes())
+	}
+}
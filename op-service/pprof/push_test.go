@@ -0,0 +1,47 @@
+package pprof
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+func TestPush(t *testing.T) {
+	var mu sync.Mutex
+	received := make(map[string]int)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/ingest", r.URL.Path)
+		require.Equal(t, "pprof", r.URL.Query().Get("format"))
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.NotEmpty(t, body)
+		mu.Lock()
+		received[r.URL.Query().Get("name")]++
+		mu.Unlock()
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- Push(ctx, testlog.Logger(t, log.LvlInfo), "test-app", srv.URL, 50*time.Millisecond)
+	}()
+
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return received["test-app.cpu"] > 0 && received["test-app.heap"] > 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	cancel()
+	require.NoError(t, <-done)
+}
@@ -10,7 +10,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-service/httputil"
 )
 
-func ListenAndServe(ctx context.Context, hostname string, port int) error {
+func ListenAndServe(ctx context.Context, hostname string, port int, opts ...httputil.ServerOption) error {
 	mux := http.NewServeMux()
 
 	// have to do below to support multiple servers, since the
@@ -26,5 +26,8 @@ func ListenAndServe(ctx context.Context, hostname string, port int) error {
 		Addr:    addr,
 		Handler: mux,
 	}
+	if err := httputil.ApplyOptions(server, opts...); err != nil {
+		return err
+	}
 	return httputil.ListenAndServeContext(ctx, server)
 }
@@ -5,7 +5,7 @@ import (
 	"math"
 
 	opservice "github.com/ethereum-optimism/optimism/op-service"
-	"github.com/urfave/cli"
+	"github.com/urfave/cli/v2"
 )
 
 const (
@@ -15,17 +15,19 @@ const (
 
 func CLIFlags(envPrefix string) []cli.Flag {
 	return []cli.Flag{
-		cli.StringFlag{
-			Name:   ListenAddrFlagName,
-			Usage:  "rpc listening address",
-			Value:  "0.0.0.0",
-			EnvVar: opservice.PrefixEnvVar(envPrefix, "RPC_ADDR"),
+		&cli.StringFlag{
+			Name:     ListenAddrFlagName,
+			Usage:    "rpc listening address",
+			Value:    "0.0.0.0",
+			EnvVars:  []string{opservice.PrefixEnvVar(envPrefix, "RPC_ADDR")},
+			Category: opservice.RPCCategory,
 		},
-		cli.IntFlag{
-			Name:   PortFlagName,
-			Usage:  "rpc listening port",
-			Value:  8545,
-			EnvVar: opservice.PrefixEnvVar(envPrefix, "RPC_PORT"),
+		&cli.IntFlag{
+			Name:     PortFlagName,
+			Usage:    "rpc listening port",
+			Value:    8545,
+			EnvVars:  []string{opservice.PrefixEnvVar(envPrefix, "RPC_PORT")},
+			Category: opservice.RPCCategory,
 		},
 	}
 }
@@ -45,7 +47,7 @@ func (c CLIConfig) Check() error {
 
 func ReadCLIConfig(ctx *cli.Context) CLIConfig {
 	return CLIConfig{
-		ListenAddr: ctx.GlobalString(ListenAddrFlagName),
-		ListenPort: ctx.GlobalInt(PortFlagName),
+		ListenAddr: ctx.String(ListenAddrFlagName),
+		ListenPort: ctx.Int(PortFlagName),
 	}
 }
@@ -36,6 +36,7 @@ type Server struct {
 	log            log.Logger
 	tls            *ServerTLSConfig
 	middlewares    []Middleware
+	handlers       map[string]http.Handler
 }
 
 type ServerTLSConfig struct {
@@ -117,6 +118,17 @@ func WithMiddleware(middleware func(http.Handler) (hdlr http.Handler)) ServerOpt
 	}
 }
 
+// WithHTTPHandler serves an additional plain HTTP handler at the given path,
+// next to the RPC and health endpoints.
+func WithHTTPHandler(path string, hdlr http.Handler) ServerOption {
+	return func(b *Server) {
+		if b.handlers == nil {
+			b.handlers = make(map[string]http.Handler)
+		}
+		b.handlers[path] = hdlr
+	}
+}
+
 func NewServer(host string, port int, appVersion string, opts ...ServerOption) *Server {
 	endpoint := net.JoinHostPort(host, strconv.Itoa(port))
 	bs := &Server{
@@ -172,6 +184,9 @@ func (b *Server) Start() error {
 	mux := http.NewServeMux()
 	mux.Handle(b.rpcPath, nodeHdlr)
 	mux.Handle(b.healthzPath, b.healthzHandler)
+	for path, hdlr := range b.handlers {
+		mux.Handle(path, hdlr)
+	}
 
 	// http middleware
 	var handler http.Handler = mux
@@ -0,0 +1,224 @@
+package txmgr
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/op-service/txmgr/metrics"
+)
+
+// ErrCircuitOpen is returned by L1 calls while the circuit breaker is open.
+var ErrCircuitOpen = errors.New("L1 circuit breaker open")
+
+// circuitBreaker is an [ETHBackend] that stops calling the L1 backend after a
+// number of consecutive failed calls. While it is open, calls fail right away
+// with ErrCircuitOpen, and sends wait, instead of piling up timeouts and logs
+// during an outage. After the cooldown, the backend is probed with a block
+// number call, and the breaker closes again if that succeeds.
+type circuitBreaker struct {
+	backend   ETHBackend
+	threshold uint64
+	cooldown  time.Duration
+	timeout   time.Duration
+	l         log.Logger
+	metr      metrics.TxMetricer
+	now       func() time.Time
+
+	// mu is held while probing, so that concurrent calls wait for its outcome
+	mu       sync.Mutex
+	failures uint64
+	// openUntil is the end of the cooldown, zero if the breaker is closed
+	openUntil time.Time
+}
+
+var _ ETHBackend = (*circuitBreaker)(nil)
+
+func newCircuitBreaker(backend ETHBackend, threshold uint64, cooldown, timeout time.Duration, l log.Logger, m metrics.TxMetricer) *circuitBreaker {
+	return &circuitBreaker{
+		backend:   backend,
+		threshold: threshold,
+		cooldown:  cooldown,
+		timeout:   timeout,
+		l:         l,
+		metr:      m,
+		now:       time.Now,
+	}
+}
+
+// allow returns nil if the breaker is closed, or could be closed by a successful
+// probe, and ErrCircuitOpen otherwise.
+func (b *circuitBreaker) allow(ctx context.Context) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.openUntil.IsZero() {
+		return nil
+	}
+	if b.now().Before(b.openUntil) {
+		return ErrCircuitOpen
+	}
+	ctx, cancel := context.WithTimeout(ctx, b.timeout)
+	defer cancel()
+	if _, err := b.backend.BlockNumber(ctx); isTransportError(err) {
+		b.l.Warn("L1 still unavailable, keeping circuit breaker open", "cooldown", b.cooldown, "err", err)
+		b.openUntil = b.now().Add(b.cooldown)
+		return ErrCircuitOpen
+	}
+	b.l.Info("L1 available again, closing circuit breaker")
+	b.failures = 0
+	b.openUntil = time.Time{}
+	b.metr.RecordCircuitOpen(false)
+	return nil
+}
+
+// observe records the outcome of a call, opening the breaker once the threshold
+// of consecutive failures is reached.
+func (b *circuitBreaker) observe(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !isTransportError(err) {
+		b.failures = 0
+		return
+	}
+	b.failures++
+	if b.failures >= b.threshold && b.openUntil.IsZero() {
+		b.l.Error("Too many consecutive L1 errors, opening circuit breaker", "failures", b.failures, "cooldown", b.cooldown, "err", err)
+		b.openUntil = b.now().Add(b.cooldown)
+		b.metr.RecordCircuitOpen(true)
+	}
+}
+
+// wait blocks until the breaker is closed.
+func (b *circuitBreaker) wait(ctx context.Context) error {
+	for {
+		if err := b.allow(ctx); err == nil {
+			return nil
+		}
+		b.mu.Lock()
+		until := b.openUntil
+		b.mu.Unlock()
+		timer := time.NewTimer(until.Sub(b.now()))
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+// isTransportError returns whether err is a failure to get a response from L1.
+// JSON-RPC errors and missing receipts are valid responses, and canceled calls
+// say nothing about the backend.
+func isTransportError(err error) bool {
+	if err == nil || errors.Is(err, ethereum.NotFound) || errors.Is(err, context.Canceled) {
+		return false
+	}
+	var rpcErr rpc.Error
+	return !errors.As(err, &rpcErr)
+}
+
+// Close closes the wrapped backend, if it can be closed.
+func (b *circuitBreaker) Close() {
+	if c, ok := b.backend.(interface{ Close() }); ok {
+		c.Close()
+	}
+}
+
+func (b *circuitBreaker) BlockNumber(ctx context.Context) (uint64, error) {
+	if err := b.allow(ctx); err != nil {
+		return 0, err
+	}
+	n, err := b.backend.BlockNumber(ctx)
+	b.observe(err)
+	return n, err
+}
+
+func (b *circuitBreaker) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	if err := b.allow(ctx); err != nil {
+		return nil, err
+	}
+	receipt, err := b.backend.TransactionReceipt(ctx, txHash)
+	b.observe(err)
+	return receipt, err
+}
+
+func (b *circuitBreaker) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	if err := b.allow(ctx); err != nil {
+		return err
+	}
+	err := b.backend.SendTransaction(ctx, tx)
+	b.observe(err)
+	return err
+}
+
+func (b *circuitBreaker) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	if err := b.allow(ctx); err != nil {
+		return nil, err
+	}
+	header, err := b.backend.HeaderByNumber(ctx, number)
+	b.observe(err)
+	return header, err
+}
+
+func (b *circuitBreaker) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	if err := b.allow(ctx); err != nil {
+		return nil, err
+	}
+	tip, err := b.backend.SuggestGasTipCap(ctx)
+	b.observe(err)
+	return tip, err
+}
+
+func (b *circuitBreaker) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	if err := b.allow(ctx); err != nil {
+		return 0, err
+	}
+	nonce, err := b.backend.NonceAt(ctx, account, blockNumber)
+	b.observe(err)
+	return nonce, err
+}
+
+func (b *circuitBreaker) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	if err := b.allow(ctx); err != nil {
+		return 0, err
+	}
+	nonce, err := b.backend.PendingNonceAt(ctx, account)
+	b.observe(err)
+	return nonce, err
+}
+
+func (b *circuitBreaker) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	if err := b.allow(ctx); err != nil {
+		return nil, err
+	}
+	balance, err := b.backend.BalanceAt(ctx, account, blockNumber)
+	b.observe(err)
+	return balance, err
+}
+
+func (b *circuitBreaker) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+	if err := b.allow(ctx); err != nil {
+		return 0, err
+	}
+	gas, err := b.backend.EstimateGas(ctx, msg)
+	b.observe(err)
+	return gas, err
+}
+
+func (b *circuitBreaker) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	if err := b.allow(ctx); err != nil {
+		return nil, err
+	}
+	res, err := b.backend.CallContract(ctx, msg, blockNumber)
+	b.observe(err)
+	return res, err
+}
@@ -0,0 +1,67 @@
+package txmgr
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr/metrics"
+)
+
+type flakyBackend struct {
+	ETHBackend
+	err   error
+	calls int
+}
+
+func (b *flakyBackend) BlockNumber(_ context.Context) (uint64, error) {
+	b.calls++
+	return 1, b.err
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	backend := &flakyBackend{err: errors.New("connection refused")}
+	b := newCircuitBreaker(backend, 2, time.Minute, time.Second, testlog.Logger(t, log.LvlCrit), &metrics.NoopTxMetrics{})
+	now := time.Unix(0, 0)
+	b.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	_, err := b.BlockNumber(ctx)
+	require.ErrorIs(t, err, backend.err)
+	_, err = b.BlockNumber(ctx)
+	require.ErrorIs(t, err, backend.err)
+	_, err = b.BlockNumber(ctx)
+	require.ErrorIs(t, err, ErrCircuitOpen)
+	require.Equal(t, 2, backend.calls, "open breaker must not call the backend")
+
+	now = now.Add(time.Minute)
+	_, err = b.BlockNumber(ctx)
+	require.ErrorIs(t, err, ErrCircuitOpen, "failed probe keeps the breaker open")
+	require.Equal(t, 3, backend.calls)
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	require.ErrorIs(t, b.wait(canceled), context.Canceled)
+
+	now = now.Add(time.Minute)
+	backend.err = nil
+	require.NoError(t, b.wait(ctx))
+	_, err = b.BlockNumber(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 5, backend.calls, "probe and call")
+}
+
+func TestCircuitBreakerIgnoresRPCErrors(t *testing.T) {
+	backend := &flakyBackend{err: testRPCError{}}
+	b := newCircuitBreaker(backend, 2, time.Minute, time.Second, testlog.Logger(t, log.LvlCrit), &metrics.NoopTxMetrics{})
+	for i := 0; i < 3; i++ {
+		_, err := b.BlockNumber(context.Background())
+		require.ErrorIs(t, err, testRPCError{})
+	}
+	require.Equal(t, 3, backend.calls)
+}
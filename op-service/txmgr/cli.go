@@ -2,9 +2,11 @@ package txmgr
 
 import (
 	"context"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"math/big"
+	"strings"
 	"time"
 
 	opservice "github.com/ethereum-optimism/optimism/op-service"
@@ -13,12 +15,20 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/log"
-	"github.com/urfave/cli"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/hashicorp/go-multierror"
+	"github.com/urfave/cli/v2"
 )
 
 const (
 	// Duplicated L1 RPC flag
 	L1RPCFlagName = "l1-eth-rpc"
+	// Additional L1 RPC endpoints of the txmgr
+	L1RPCExtraFlagName     = "txmgr.l1-eth-rpc-extra"
+	L1BroadcastFlagName    = "txmgr.l1-broadcast"
+	L1RoutingFlagName      = "txmgr.l1-routing"
+	L1RPCRateLimitFlagName = "txmgr.l1-rpc-rate-limit"
+	L1RPCRateBurstFlagName = "txmgr.l1-rpc-rate-burst"
 	// Key Management Flags (also have op-signer client flags)
 	MnemonicFlagName   = "mnemonic"
 	HDPathFlagName     = "hd-path"
@@ -27,93 +37,227 @@ const (
 	NumConfirmationsFlagName          = "num-confirmations"
 	SafeAbortNonceTooLowCountFlagName = "safe-abort-nonce-too-low-count"
 	ResubmissionTimeoutFlagName       = "resubmission-timeout"
+	ResubmissionPolicyFlagName        = "txmgr.resubmission-policy"
+	ResubmissionTimeoutMaxFlagName    = "txmgr.resubmission-timeout-max"
 	NetworkTimeoutFlagName            = "network-timeout"
 	TxSendTimeoutFlagName             = "txmgr.send-timeout"
 	TxNotInMempoolTimeoutFlagName     = "txmgr.not-in-mempool-timeout"
 	ReceiptQueryIntervalFlagName      = "txmgr.receipt-query-interval"
+	MaxPoolBacklogFlagName            = "txmgr.max-pool-backlog"
+	RateLimitThresholdFlagName        = "txmgr.rate-limit-threshold"
+	FeeCacheTTLFlagName               = "txmgr.fee-cache-ttl"
+	JournalFlagName                   = "txmgr.journal"
+	MaxTxFeeFlagName                  = "txmgr.max-tx-fee"
+	SimulateTxsFlagName               = "txmgr.simulate"
+	CircuitBreakerThresholdFlagName   = "txmgr.circuit-breaker-threshold"
+	CircuitBreakerCooldownFlagName    = "txmgr.circuit-breaker-cooldown"
+)
+
+// L1 routing strategies, see [MultiBackend].
+const (
+	L1RoutingLatency    = "latency"
+	L1RoutingRoundRobin = "round-robin"
 )
 
 var (
-	SequencerHDPathFlag = cli.StringFlag{
+	SequencerHDPathFlag = &cli.StringFlag{
 		Name: "sequencer-hd-path",
-		Usage: "DEPRECATED: The HD path used to derive the sequencer wallet from the " +
-			"mnemonic. The mnemonic flag must also be set.",
-		EnvVar: "OP_BATCHER_SEQUENCER_HD_PATH",
+		Usage: "DEPRECATED: use --" + HDPathFlagName + " instead. The HD path used to derive " +
+			"the sequencer wallet from the mnemonic. The mnemonic flag must also be set.",
+		EnvVars:  []string{"OP_BATCHER_SEQUENCER_HD_PATH"},
+		Category: opservice.SigningCategory,
 	}
-	L2OutputHDPathFlag = cli.StringFlag{
+	L2OutputHDPathFlag = &cli.StringFlag{
 		Name: "l2-output-hd-path",
-		Usage: "DEPRECATED:The HD path used to derive the l2output wallet from the " +
-			"mnemonic. The mnemonic flag must also be set.",
-		EnvVar: "OP_PROPOSER_L2_OUTPUT_HD_PATH",
+		Usage: "DEPRECATED: use --" + HDPathFlagName + " instead. The HD path used to derive " +
+			"the l2output wallet from the mnemonic. The mnemonic flag must also be set.",
+		EnvVars:  []string{"OP_PROPOSER_L2_OUTPUT_HD_PATH"},
+		Category: opservice.SigningCategory,
 	}
 )
 
 func CLIFlags(envPrefix string) []cli.Flag {
 	return append([]cli.Flag{
-		cli.StringFlag{
-			Name:   MnemonicFlagName,
-			Usage:  "The mnemonic used to derive the wallets for either the service",
-			EnvVar: opservice.PrefixEnvVar(envPrefix, "MNEMONIC"),
+		&cli.StringFlag{
+			Name:     MnemonicFlagName,
+			Usage:    "The mnemonic used to derive the wallets for either the service",
+			EnvVars:  []string{opservice.PrefixEnvVar(envPrefix, "MNEMONIC")},
+			Category: opservice.SigningCategory,
 		},
-		cli.StringFlag{
-			Name:   HDPathFlagName,
-			Usage:  "The HD path used to derive the sequencer wallet from the mnemonic. The mnemonic flag must also be set.",
-			EnvVar: opservice.PrefixEnvVar(envPrefix, "HD_PATH"),
+		&cli.StringFlag{
+			Name:     HDPathFlagName,
+			Usage:    "The HD path used to derive the sequencer wallet from the mnemonic. The mnemonic flag must also be set.",
+			EnvVars:  []string{opservice.PrefixEnvVar(envPrefix, "HD_PATH")},
+			Category: opservice.SigningCategory,
 		},
 		SequencerHDPathFlag,
 		L2OutputHDPathFlag,
-		cli.StringFlag{
-			Name:   "private-key",
-			Usage:  "The private key to use with the service. Must not be used with mnemonic.",
-			EnvVar: opservice.PrefixEnvVar(envPrefix, "PRIVATE_KEY"),
+		&cli.StringFlag{
+			Name:     "private-key",
+			Usage:    "The private key to use with the service. Must not be used with mnemonic.",
+			EnvVars:  []string{opservice.PrefixEnvVar(envPrefix, "PRIVATE_KEY")},
+			Category: opservice.SigningCategory,
+		},
+		&cli.Uint64Flag{
+			Name:     NumConfirmationsFlagName,
+			Usage:    "Number of confirmations which we will wait after sending a transaction",
+			Value:    10,
+			EnvVars:  []string{opservice.PrefixEnvVar(envPrefix, "NUM_CONFIRMATIONS")},
+			Category: opservice.TxMgrCategory,
+		},
+		&cli.Uint64Flag{
+			Name:     SafeAbortNonceTooLowCountFlagName,
+			Usage:    "Number of ErrNonceTooLow observations required to give up on a tx at a particular nonce without receiving confirmation",
+			Value:    3,
+			EnvVars:  []string{opservice.PrefixEnvVar(envPrefix, "SAFE_ABORT_NONCE_TOO_LOW_COUNT")},
+			Category: opservice.TxMgrCategory,
+		},
+		&cli.DurationFlag{
+			Name:     ResubmissionTimeoutFlagName,
+			Usage:    "Duration we will wait before resubmitting a transaction to L1",
+			Value:    48 * time.Second,
+			EnvVars:  []string{opservice.PrefixEnvVar(envPrefix, "RESUBMISSION_TIMEOUT")},
+			Category: opservice.TxMgrCategory,
+		},
+		&cli.StringFlag{
+			Name:     ResubmissionPolicyFlagName,
+			Usage:    "How long to wait between resubmissions: '" + RetryPolicyFixed + "' always waits --" + ResubmissionTimeoutFlagName + ", '" + RetryPolicyExponential + "' starts there and doubles with jitter, up to --" + ResubmissionTimeoutMaxFlagName + ".",
+			Value:    RetryPolicyFixed,
+			EnvVars:  []string{opservice.PrefixEnvVar(envPrefix, "TXMGR_RESUBMISSION_POLICY")},
+			Category: opservice.TxMgrCategory,
+		},
+		&cli.DurationFlag{
+			Name:     ResubmissionTimeoutMaxFlagName,
+			Usage:    "Maximum duration to wait before resubmitting a transaction with the exponential resubmission policy",
+			Value:    10 * time.Minute,
+			EnvVars:  []string{opservice.PrefixEnvVar(envPrefix, "TXMGR_RESUBMISSION_TIMEOUT_MAX")},
+			Category: opservice.TxMgrCategory,
+		},
+		&cli.DurationFlag{
+			Name:     NetworkTimeoutFlagName,
+			Usage:    "Timeout for all network operations",
+			Value:    2 * time.Second,
+			EnvVars:  []string{opservice.PrefixEnvVar(envPrefix, "NETWORK_TIMEOUT")},
+			Category: opservice.TxMgrCategory,
+		},
+		&cli.DurationFlag{
+			Name:     TxSendTimeoutFlagName,
+			Usage:    "Timeout for sending transactions. If 0 it is disabled.",
+			Value:    0,
+			EnvVars:  []string{opservice.PrefixEnvVar(envPrefix, "TXMGR_TX_SEND_TIMEOUT")},
+			Category: opservice.TxMgrCategory,
+		},
+		&cli.DurationFlag{
+			Name:     TxNotInMempoolTimeoutFlagName,
+			Usage:    "Timeout for aborting a tx send if the tx does not make it to the mempool.",
+			Value:    2 * time.Minute,
+			EnvVars:  []string{opservice.PrefixEnvVar(envPrefix, "TXMGR_TX_NOT_IN_MEMPOOL_TIMEOUT")},
+			Category: opservice.TxMgrCategory,
+		},
+		&cli.DurationFlag{
+			Name:     ReceiptQueryIntervalFlagName,
+			Usage:    "Frequency to poll for receipts",
+			Value:    12 * time.Second,
+			EnvVars:  []string{opservice.PrefixEnvVar(envPrefix, "TXMGR_RECEIPT_QUERY_INTERVAL")},
+			Category: opservice.TxMgrCategory,
+		},
+		&cli.Uint64Flag{
+			Name:     MaxPoolBacklogFlagName,
+			Usage:    "Refuse to send when the account has more pending transactions in the L1 mempool that were not sent by this instance, e.g. by a second instance. If 0 it is disabled.",
+			Value:    0,
+			EnvVars:  []string{opservice.PrefixEnvVar(envPrefix, "TXMGR_MAX_POOL_BACKLOG")},
+			Category: opservice.TxMgrCategory,
+		},
+		&cli.StringSliceFlag{
+			Name:     L1RPCExtraFlagName,
+			Usage:    "Additional HTTP provider URLs for L1. Reads are routed to the fastest healthy endpoint and transactions are submitted through the most reliable one.",
+			EnvVars:  []string{opservice.PrefixEnvVar(envPrefix, "TXMGR_L1_ETH_RPC_EXTRA")},
+			Category: opservice.TxMgrCategory,
+		},
+		&cli.StringFlag{
+			Name:     L1RoutingFlagName,
+			Usage:    "How calls are routed across the L1 endpoints: 'latency' routes reads to the fastest healthy endpoint and transactions to the most reliable one, 'round-robin' spreads reads evenly and pins transactions to --" + L1RPCFlagName + ".",
+			Value:    L1RoutingLatency,
+			EnvVars:  []string{opservice.PrefixEnvVar(envPrefix, "TXMGR_L1_ROUTING")},
+			Category: opservice.TxMgrCategory,
+		},
+		&cli.Float64Flag{
+			Name:     L1RPCRateLimitFlagName,
+			Usage:    "Self-imposed rate limit on L1 RPC requests of the txmgr, in requests per second, shared by all L1 endpoints. If 0 it is disabled.",
+			Value:    0,
+			EnvVars:  []string{opservice.PrefixEnvVar(envPrefix, "TXMGR_L1_RPC_RATE_LIMIT")},
+			Category: opservice.TxMgrCategory,
+		},
+		&cli.IntFlag{
+			Name:     L1RPCRateBurstFlagName,
+			Usage:    "Number of L1 RPC requests that may exceed the rate limit at once",
+			Value:    10,
+			EnvVars:  []string{opservice.PrefixEnvVar(envPrefix, "TXMGR_L1_RPC_RATE_BURST")},
+			Category: opservice.TxMgrCategory,
 		},
-		cli.Uint64Flag{
-			Name:   NumConfirmationsFlagName,
-			Usage:  "Number of confirmations which we will wait after sending a transaction",
-			Value:  10,
-			EnvVar: opservice.PrefixEnvVar(envPrefix, "NUM_CONFIRMATIONS"),
+		&cli.BoolFlag{
+			Name:     L1BroadcastFlagName,
+			Usage:    "Submit transactions through all L1 endpoints concurrently, instead of only the most reliable one. Requires extra L1 endpoints.",
+			EnvVars:  []string{opservice.PrefixEnvVar(envPrefix, "TXMGR_L1_BROADCAST")},
+			Category: opservice.TxMgrCategory,
 		},
-		cli.Uint64Flag{
-			Name:   SafeAbortNonceTooLowCountFlagName,
-			Usage:  "Number of ErrNonceTooLow observations required to give up on a tx at a particular nonce without receiving confirmation",
-			Value:  3,
-			EnvVar: opservice.PrefixEnvVar(envPrefix, "SAFE_ABORT_NONCE_TOO_LOW_COUNT"),
+		&cli.Uint64Flag{
+			Name:     RateLimitThresholdFlagName,
+			Usage:    "Number of rate limited (HTTP 429) L1 RPC responses per minute after which load is shed: send concurrency is halved and the resubmission timeout doubled, up to 3 times. If 0 it is disabled.",
+			Value:    0,
+			EnvVars:  []string{opservice.PrefixEnvVar(envPrefix, "TXMGR_RATE_LIMIT_THRESHOLD")},
+			Category: opservice.TxMgrCategory,
 		},
-		cli.DurationFlag{
-			Name:   ResubmissionTimeoutFlagName,
-			Usage:  "Duration we will wait before resubmitting a transaction to L1",
-			Value:  48 * time.Second,
-			EnvVar: opservice.PrefixEnvVar(envPrefix, "RESUBMISSION_TIMEOUT"),
+		&cli.DurationFlag{
+			Name:     FeeCacheTTLFlagName,
+			Usage:    "Duration for which suggested fees are reused to craft new transactions, unless a new L1 block is seen earlier. Reduces L1 RPC load during bursts of sends. If 0 it is disabled.",
+			Value:    0,
+			EnvVars:  []string{opservice.PrefixEnvVar(envPrefix, "TXMGR_FEE_CACHE_TTL")},
+			Category: opservice.TxMgrCategory,
 		},
-		cli.DurationFlag{
-			Name:   NetworkTimeoutFlagName,
-			Usage:  "Timeout for all network operations",
-			Value:  2 * time.Second,
-			EnvVar: opservice.PrefixEnvVar(envPrefix, "NETWORK_TIMEOUT"),
+		&cli.StringFlag{
+			Name:     JournalFlagName,
+			Usage:    "Path of a file in which in-flight transactions are journaled, so that after a restart, sending the same data resumes them and new transactions replace the others with the same nonce. If empty it is disabled.",
+			EnvVars:  []string{opservice.PrefixEnvVar(envPrefix, "TXMGR_JOURNAL")},
+			Category: opservice.TxMgrCategory,
 		},
-		cli.DurationFlag{
-			Name:   TxSendTimeoutFlagName,
-			Usage:  "Timeout for sending transactions. If 0 it is disabled.",
-			Value:  0,
-			EnvVar: opservice.PrefixEnvVar(envPrefix, "TXMGR_TX_SEND_TIMEOUT"),
+		&cli.Uint64Flag{
+			Name:     MaxTxFeeFlagName,
+			Usage:    "Maximum fee of a single transaction in gwei, at its fee cap. Sends abort instead of crafting or fee bumping a transaction above it. If 0 it is disabled.",
+			Value:    0,
+			EnvVars:  []string{opservice.PrefixEnvVar(envPrefix, "TXMGR_MAX_TX_FEE")},
+			Category: opservice.TxMgrCategory,
 		},
-		cli.DurationFlag{
-			Name:   TxNotInMempoolTimeoutFlagName,
-			Usage:  "Timeout for aborting a tx send if the tx does not make it to the mempool.",
-			Value:  2 * time.Minute,
-			EnvVar: opservice.PrefixEnvVar(envPrefix, "TXMGR_TX_NOT_IN_MEMPOOL_TIMEOUT"),
+		&cli.BoolFlag{
+			Name:     SimulateTxsFlagName,
+			Usage:    "Simulate transactions with eth_call before signing them, so that sends that would revert fail right away with the revert reason instead of being published.",
+			EnvVars:  []string{opservice.PrefixEnvVar(envPrefix, "TXMGR_SIMULATE")},
+			Category: opservice.TxMgrCategory,
 		},
-		cli.DurationFlag{
-			Name:   ReceiptQueryIntervalFlagName,
-			Usage:  "Frequency to poll for receipts",
-			Value:  12 * time.Second,
-			EnvVar: opservice.PrefixEnvVar(envPrefix, "TXMGR_RECEIPT_QUERY_INTERVAL"),
+		&cli.Uint64Flag{
+			Name:     CircuitBreakerThresholdFlagName,
+			Usage:    "Number of consecutive failed L1 RPC calls after which L1 calls fail fast and sends pause for the circuit breaker cooldown. If 0 it is disabled.",
+			Value:    0,
+			EnvVars:  []string{opservice.PrefixEnvVar(envPrefix, "TXMGR_CIRCUIT_BREAKER_THRESHOLD")},
+			Category: opservice.TxMgrCategory,
+		},
+		&cli.DurationFlag{
+			Name:     CircuitBreakerCooldownFlagName,
+			Usage:    "Duration for which the circuit breaker stays open before L1 is probed again",
+			Value:    30 * time.Second,
+			EnvVars:  []string{opservice.PrefixEnvVar(envPrefix, "TXMGR_CIRCUIT_BREAKER_COOLDOWN")},
+			Category: opservice.TxMgrCategory,
 		},
 	}, client.CLIFlags(envPrefix)...)
 }
 
 type CLIConfig struct {
 	L1RPCURL                  string
+	L1RPCExtraURLs            []string
+	L1Routing                 string
+	L1Broadcast               bool
+	L1RPCRateLimit            float64
+	L1RPCRateBurst            int
 	Mnemonic                  string
 	HDPath                    string
 	SequencerHDPath           string
@@ -123,56 +267,167 @@ type CLIConfig struct {
 	NumConfirmations          uint64
 	SafeAbortNonceTooLowCount uint64
 	ResubmissionTimeout       time.Duration
+	ResubmissionPolicy        string
+	ResubmissionTimeoutMax    time.Duration
 	ReceiptQueryInterval      time.Duration
 	NetworkTimeout            time.Duration
 	TxSendTimeout             time.Duration
 	TxNotInMempoolTimeout     time.Duration
+	MaxPoolBacklog            uint64
+	RateLimitThreshold        uint64
+	FeeCacheTTL               time.Duration
+	JournalPath               string
+	MaxTxFeeGwei              uint64
+	SimulateTxs               bool
+	CircuitBreakerThreshold   uint64
+	CircuitBreakerCooldown    time.Duration
 }
 
+// Check validates the config. It reports all violations at once, so that
+// operators don't have to fix misconfigurations one restart at a time.
 func (m CLIConfig) Check() error {
+	var result *multierror.Error
 	if m.L1RPCURL == "" {
-		return errors.New("must provide a L1 RPC url")
+		result = multierror.Append(result, errors.New("must provide a L1 RPC url"))
+	} else if err := opservice.CheckRPCURL(m.L1RPCURL); err != nil {
+		result = multierror.Append(result, fmt.Errorf("L1 RPC: %w", err))
+	}
+	for _, url := range m.L1RPCExtraURLs {
+		if err := opservice.CheckRPCURL(url); err != nil {
+			result = multierror.Append(result, fmt.Errorf("extra L1 RPC: %w", err))
+		}
+	}
+	if m.L1Routing != "" && m.L1Routing != L1RoutingLatency && m.L1Routing != L1RoutingRoundRobin {
+		result = multierror.Append(result, fmt.Errorf("unknown L1 routing %q, must be %s or %s", m.L1Routing, L1RoutingLatency, L1RoutingRoundRobin))
+	}
+	if m.L1Broadcast && len(m.L1RPCExtraURLs) == 0 {
+		result = multierror.Append(result, errors.New("broadcasting transactions requires extra L1 RPC urls"))
+	}
+	if m.L1RPCRateLimit < 0 {
+		result = multierror.Append(result, errors.New("L1RPCRateLimit must not be negative"))
 	}
 	if m.NumConfirmations == 0 {
-		return errors.New("NumConfirmations must not be 0")
+		result = multierror.Append(result, errors.New("NumConfirmations must not be 0"))
 	}
 	if m.NetworkTimeout == 0 {
-		return errors.New("must provide NetworkTimeout")
+		result = multierror.Append(result, errors.New("must provide NetworkTimeout"))
 	}
 	if m.ResubmissionTimeout == 0 {
-		return errors.New("must provide ResubmissionTimeout")
+		result = multierror.Append(result, errors.New("must provide ResubmissionTimeout"))
+	}
+	switch m.ResubmissionPolicy {
+	case "", RetryPolicyFixed:
+	case RetryPolicyExponential:
+		if m.ResubmissionTimeoutMax < m.ResubmissionTimeout {
+			result = multierror.Append(result, fmt.Errorf("ResubmissionTimeoutMax (%v) must not be less than ResubmissionTimeout (%v)",
+				m.ResubmissionTimeoutMax, m.ResubmissionTimeout))
+		}
+	default:
+		result = multierror.Append(result, fmt.Errorf("unknown resubmission policy %q, must be %s or %s", m.ResubmissionPolicy, RetryPolicyFixed, RetryPolicyExponential))
 	}
 	if m.ReceiptQueryInterval == 0 {
-		return errors.New("must provide ReceiptQueryInterval")
+		result = multierror.Append(result, errors.New("must provide ReceiptQueryInterval"))
+	} else if m.ResubmissionTimeout != 0 && m.ReceiptQueryInterval >= m.ResubmissionTimeout {
+		result = multierror.Append(result, fmt.Errorf("ReceiptQueryInterval (%v) must be less than ResubmissionTimeout (%v)",
+			m.ReceiptQueryInterval, m.ResubmissionTimeout))
 	}
 	if m.TxNotInMempoolTimeout == 0 {
-		return errors.New("must provide TxNotInMempoolTimeout")
+		result = multierror.Append(result, errors.New("must provide TxNotInMempoolTimeout"))
+	}
+	if m.TxSendTimeout != 0 && m.TxSendTimeout < m.ResubmissionTimeout {
+		result = multierror.Append(result, fmt.Errorf("TxSendTimeout (%v) must not be less than ResubmissionTimeout (%v), or the fee would never be bumped",
+			m.TxSendTimeout, m.ResubmissionTimeout))
+	}
+	if m.CircuitBreakerThreshold != 0 && m.CircuitBreakerCooldown == 0 {
+		result = multierror.Append(result, errors.New("must provide CircuitBreakerCooldown with a CircuitBreakerThreshold"))
 	}
 	if m.SafeAbortNonceTooLowCount == 0 {
-		return errors.New("SafeAbortNonceTooLowCount must not be 0")
+		result = multierror.Append(result, errors.New("SafeAbortNonceTooLowCount must not be 0"))
+	}
+	if m.PrivateKey != "" && !isHexPrivateKey(m.PrivateKey) {
+		// Never include the key itself in the error.
+		result = multierror.Append(result, errors.New("private key must be 32 hex encoded bytes"))
 	}
 	if err := m.SignerCLIConfig.Check(); err != nil {
-		return err
+		result = multierror.Append(result, err)
+	}
+	if err := m.checkKeyManagement(); err != nil {
+		result = multierror.Append(result, err)
+	}
+	return result.ErrorOrNil()
+}
+
+func isHexPrivateKey(key string) bool {
+	key = strings.TrimPrefix(key, "0x")
+	if len(key) != 64 {
+		return false
+	}
+	_, err := hex.DecodeString(key)
+	return err == nil
+}
+
+// checkKeyManagement rejects combinations of key management flags that cannot
+// all be honored, instead of silently ignoring some of them.
+func (m CLIConfig) checkKeyManagement() error {
+	if m.PrivateKey != "" && m.Mnemonic != "" {
+		return errors.New("cannot specify both a private key and a mnemonic")
+	}
+	if m.SignerCLIConfig.Enabled() && (m.PrivateKey != "" || m.Mnemonic != "") {
+		return errors.New("cannot specify a private key or mnemonic when using a remote signer")
+	}
+	if m.SequencerHDPath != "" && m.L2OutputHDPath != "" {
+		return fmt.Errorf("cannot specify both deprecated flags %s and %s, use %s instead",
+			SequencerHDPathFlag.Name, L2OutputHDPathFlag.Name, HDPathFlagName)
+	}
+	if legacy := m.legacyHDPath(); m.HDPath != "" && legacy != "" && legacy != m.HDPath {
+		return fmt.Errorf("conflicting HD paths %q and %q, only set %s", m.HDPath, legacy, HDPathFlagName)
+	}
+	if m.Mnemonic == "" && (m.HDPath != "" || m.legacyHDPath() != "") {
+		return errors.New("an HD path can only be used together with a mnemonic")
 	}
 	return nil
 }
 
+// legacyHDPath returns the HD path set by one of the deprecated HD path flags,
+// if any.
+func (m CLIConfig) legacyHDPath() string {
+	if m.SequencerHDPath != "" {
+		return m.SequencerHDPath
+	}
+	return m.L2OutputHDPath
+}
+
 func ReadCLIConfig(ctx *cli.Context) CLIConfig {
 	return CLIConfig{
-		L1RPCURL:                  ctx.GlobalString(L1RPCFlagName),
-		Mnemonic:                  ctx.GlobalString(MnemonicFlagName),
-		HDPath:                    ctx.GlobalString(HDPathFlagName),
-		SequencerHDPath:           ctx.GlobalString(SequencerHDPathFlag.Name),
-		L2OutputHDPath:            ctx.GlobalString(L2OutputHDPathFlag.Name),
-		PrivateKey:                ctx.GlobalString(PrivateKeyFlagName),
+		L1RPCURL:                  ctx.String(L1RPCFlagName),
+		L1RPCExtraURLs:            ctx.StringSlice(L1RPCExtraFlagName),
+		L1Routing:                 ctx.String(L1RoutingFlagName),
+		L1Broadcast:               ctx.Bool(L1BroadcastFlagName),
+		L1RPCRateLimit:            ctx.Float64(L1RPCRateLimitFlagName),
+		L1RPCRateBurst:            ctx.Int(L1RPCRateBurstFlagName),
+		Mnemonic:                  ctx.String(MnemonicFlagName),
+		HDPath:                    ctx.String(HDPathFlagName),
+		SequencerHDPath:           ctx.String(SequencerHDPathFlag.Name),
+		L2OutputHDPath:            ctx.String(L2OutputHDPathFlag.Name),
+		PrivateKey:                ctx.String(PrivateKeyFlagName),
 		SignerCLIConfig:           client.ReadCLIConfig(ctx),
-		NumConfirmations:          ctx.GlobalUint64(NumConfirmationsFlagName),
-		SafeAbortNonceTooLowCount: ctx.GlobalUint64(SafeAbortNonceTooLowCountFlagName),
-		ResubmissionTimeout:       ctx.GlobalDuration(ResubmissionTimeoutFlagName),
-		ReceiptQueryInterval:      ctx.GlobalDuration(ReceiptQueryIntervalFlagName),
-		NetworkTimeout:            ctx.GlobalDuration(NetworkTimeoutFlagName),
-		TxSendTimeout:             ctx.GlobalDuration(TxSendTimeoutFlagName),
-		TxNotInMempoolTimeout:     ctx.GlobalDuration(TxNotInMempoolTimeoutFlagName),
+		NumConfirmations:          ctx.Uint64(NumConfirmationsFlagName),
+		SafeAbortNonceTooLowCount: ctx.Uint64(SafeAbortNonceTooLowCountFlagName),
+		ResubmissionTimeout:       ctx.Duration(ResubmissionTimeoutFlagName),
+		ResubmissionPolicy:        ctx.String(ResubmissionPolicyFlagName),
+		ResubmissionTimeoutMax:    ctx.Duration(ResubmissionTimeoutMaxFlagName),
+		ReceiptQueryInterval:      ctx.Duration(ReceiptQueryIntervalFlagName),
+		NetworkTimeout:            ctx.Duration(NetworkTimeoutFlagName),
+		TxSendTimeout:             ctx.Duration(TxSendTimeoutFlagName),
+		TxNotInMempoolTimeout:     ctx.Duration(TxNotInMempoolTimeoutFlagName),
+		MaxPoolBacklog:            ctx.Uint64(MaxPoolBacklogFlagName),
+		RateLimitThreshold:        ctx.Uint64(RateLimitThresholdFlagName),
+		FeeCacheTTL:               ctx.Duration(FeeCacheTTLFlagName),
+		JournalPath:               ctx.String(JournalFlagName),
+		MaxTxFeeGwei:              ctx.Uint64(MaxTxFeeFlagName),
+		SimulateTxs:               ctx.Bool(SimulateTxsFlagName),
+		CircuitBreakerThreshold:   ctx.Uint64(CircuitBreakerThresholdFlagName),
+		CircuitBreakerCooldown:    ctx.Duration(CircuitBreakerCooldownFlagName),
 	}
 }
 
@@ -195,12 +450,32 @@ func NewConfig(cfg CLIConfig, l log.Logger) (Config, error) {
 		return Config{}, fmt.Errorf("could not dial fetch L1 chain ID: %w", err)
 	}
 
+	var backend ETHBackend = l1
+	if len(cfg.L1RPCExtraURLs) > 0 {
+		backends := []ETHBackend{l1}
+		for _, url := range cfg.L1RPCExtraURLs {
+			extra, err := dialL1(url, chainID, cfg.NetworkTimeout)
+			if err != nil {
+				return Config{}, err
+			}
+			backends = append(backends, extra)
+		}
+		mb := NewMultiBackend(backends...)
+		mb.RoundRobin = cfg.L1Routing == L1RoutingRoundRobin
+		mb.Broadcast = cfg.L1Broadcast
+		backend = mb
+	}
+
 	// Allow backwards compatible ways of specifying the HD path
+	if cfg.SequencerHDPath != "" {
+		l.Warn("Deprecated flag used, use the replacement flag instead", "flag", SequencerHDPathFlag.Name, "replacement", HDPathFlagName)
+	}
+	if cfg.L2OutputHDPath != "" {
+		l.Warn("Deprecated flag used, use the replacement flag instead", "flag", L2OutputHDPathFlag.Name, "replacement", HDPathFlagName)
+	}
 	hdPath := cfg.HDPath
-	if hdPath == "" && cfg.SequencerHDPath != "" {
-		hdPath = cfg.SequencerHDPath
-	} else if hdPath == "" && cfg.L2OutputHDPath != "" {
-		hdPath = cfg.L2OutputHDPath
+	if hdPath == "" {
+		hdPath = cfg.legacyHDPath()
 	}
 
 	signerFactory, from, err := opcrypto.SignerFactoryFromConfig(l, cfg.PrivateKey, cfg.Mnemonic, hdPath, cfg.SignerCLIConfig)
@@ -208,9 +483,20 @@ func NewConfig(cfg CLIConfig, l log.Logger) (Config, error) {
 		return Config{}, fmt.Errorf("could not init signer: %w", err)
 	}
 
+	var retryPolicy RetryPolicy
+	if cfg.ResubmissionPolicy == RetryPolicyExponential {
+		retryPolicy = ExponentialRetryPolicy(cfg.ResubmissionTimeout, cfg.ResubmissionTimeoutMax)
+	}
+
+	var maxTxFee *big.Int
+	if cfg.MaxTxFeeGwei != 0 {
+		maxTxFee = new(big.Int).Mul(new(big.Int).SetUint64(cfg.MaxTxFeeGwei), big.NewInt(params.GWei))
+	}
+
 	return Config{
-		Backend:                   l1,
+		Backend:                   backend,
 		ResubmissionTimeout:       cfg.ResubmissionTimeout,
+		RetryPolicy:               retryPolicy,
 		ChainID:                   chainID,
 		TxSendTimeout:             cfg.TxSendTimeout,
 		TxNotInMempoolTimeout:     cfg.TxNotInMempoolTimeout,
@@ -218,11 +504,39 @@ func NewConfig(cfg CLIConfig, l log.Logger) (Config, error) {
 		ReceiptQueryInterval:      cfg.ReceiptQueryInterval,
 		NumConfirmations:          cfg.NumConfirmations,
 		SafeAbortNonceTooLowCount: cfg.SafeAbortNonceTooLowCount,
+		MaxPoolBacklog:            cfg.MaxPoolBacklog,
+		RateLimitThreshold:        cfg.RateLimitThreshold,
+		FeeCacheTTL:               cfg.FeeCacheTTL,
+		JournalPath:               cfg.JournalPath,
+		MaxTxFee:                  maxTxFee,
+		SimulateTxs:               cfg.SimulateTxs,
+		CircuitBreakerThreshold:   cfg.CircuitBreakerThreshold,
+		CircuitBreakerCooldown:    cfg.CircuitBreakerCooldown,
+		L1RPCRateLimit:            cfg.L1RPCRateLimit,
+		L1RPCRateBurst:            cfg.L1RPCRateBurst,
 		Signer:                    signerFactory(chainID),
 		From:                      from,
 	}, nil
 }
 
+// dialL1 dials an additional L1 endpoint and ensures that it serves the expected chain.
+func dialL1(url string, chainID *big.Int, timeout time.Duration) (*ethclient.Client, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	l1, err := ethclient.DialContext(ctx, url)
+	if err != nil {
+		return nil, fmt.Errorf("could not dial extra eth client: %w", err)
+	}
+	id, err := l1.ChainID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch chain ID of extra L1 endpoint: %w", err)
+	}
+	if id.Cmp(chainID) != 0 {
+		return nil, fmt.Errorf("extra L1 endpoint serves chain %v, expected %v", id, chainID)
+	}
+	return l1, nil
+}
+
 // Config houses parameters for altering the behavior of a SimpleTxManager.
 type Config struct {
 	Backend ETHBackend
@@ -232,6 +546,10 @@ type Config struct {
 	// attempted.
 	ResubmissionTimeout time.Duration
 
+	// RetryPolicy optionally replaces the fixed ResubmissionTimeout, to vary
+	// the interval between resubmissions.
+	RetryPolicy RetryPolicy
+
 	// ChainID is the chain ID of the L1 chain.
 	ChainID *big.Int
 
@@ -261,6 +579,48 @@ type Config struct {
 	// confirmation.
 	SafeAbortNonceTooLowCount uint64
 
+	// MaxPoolBacklog is the maximum number of pending mempool transactions of the
+	// sender that were not sent by this transaction manager. Sending is refused
+	// above it, as it usually means that another instance uses the same account.
+	// Zero disables the check.
+	MaxPoolBacklog uint64
+
+	// RateLimitThreshold is the number of rate limited L1 RPC responses within a
+	// minute after which the transaction manager sheds load, see [loadShedder].
+	// Zero disables load shedding.
+	RateLimitThreshold uint64
+
+	// FeeCacheTTL is how long suggested fees are reused to craft new
+	// transactions. Fees are also refreshed once a new block is seen. Zero
+	// disables the cache.
+	FeeCacheTTL time.Duration
+
+	// JournalPath is the file in which the transactions of in-flight sends are
+	// journaled, see [journal]. Empty disables the journal.
+	JournalPath string
+
+	// MaxTxFee is the maximum fee in wei that a single transaction may pay at
+	// its fee cap. Sends abort with ErrMaxTxFee instead of exceeding it. Nil
+	// disables the maximum.
+	MaxTxFee *big.Int
+
+	// SimulateTxs enables executing new transactions with eth_call before
+	// signing them. Sends of transactions that would revert fail with
+	// ErrSimulationFailed. Fee bumps are not simulated again.
+	SimulateTxs bool
+
+	// CircuitBreakerThreshold is the number of consecutive failed L1 calls after
+	// which the [circuitBreaker] opens for CircuitBreakerCooldown. Zero disables
+	// the circuit breaker.
+	CircuitBreakerThreshold uint64
+	CircuitBreakerCooldown  time.Duration
+
+	// L1RPCRateLimit limits the L1 RPC requests of the transaction manager to
+	// the given number per second, with bursts of up to L1RPCRateBurst. Zero
+	// disables the rate limit.
+	L1RPCRateLimit float64
+	L1RPCRateBurst int
+
 	// Signer is used to sign transactions when the gas price is increased.
 	Signer opcrypto.SignerFn
 	From   common.Address
@@ -0,0 +1,128 @@
+package txmgr
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-signer/client"
+)
+
+func validCLIConfig() CLIConfig {
+	return CLIConfig{
+		L1RPCURL:                  "http://localhost:8545",
+		NumConfirmations:          1,
+		SafeAbortNonceTooLowCount: 3,
+		ResubmissionTimeout:       time.Second,
+		ReceiptQueryInterval:      100 * time.Millisecond,
+		NetworkTimeout:            time.Second,
+		TxNotInMempoolTimeout:     time.Second,
+	}
+}
+
+func TestCLIConfigCheckKeyManagement(t *testing.T) {
+	const (
+		hdPath     = "m/44'/60'/0'/0/1"
+		privateKey = "0x0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+	)
+	tests := []struct {
+		name   string
+		modify func(*CLIConfig)
+		errMsg string
+	}{
+		{
+			name:   "private key",
+			modify: func(c *CLIConfig) { c.PrivateKey = privateKey },
+		},
+		{
+			name: "mnemonic with hd path",
+			modify: func(c *CLIConfig) {
+				c.Mnemonic = "test"
+				c.HDPath = hdPath
+			},
+		},
+		{
+			name: "mnemonic with legacy hd path",
+			modify: func(c *CLIConfig) {
+				c.Mnemonic = "test"
+				c.SequencerHDPath = hdPath
+			},
+		},
+		{
+			name: "hd path equal to legacy hd path",
+			modify: func(c *CLIConfig) {
+				c.Mnemonic = "test"
+				c.HDPath = hdPath
+				c.L2OutputHDPath = hdPath
+			},
+		},
+		{
+			name: "private key and mnemonic",
+			modify: func(c *CLIConfig) {
+				c.PrivateKey = privateKey
+				c.Mnemonic = "test"
+			},
+			errMsg: "cannot specify both a private key and a mnemonic",
+		},
+		{
+			name: "remote signer and private key",
+			modify: func(c *CLIConfig) {
+				c.PrivateKey = privateKey
+				c.SignerCLIConfig = client.CLIConfig{Endpoint: "http://localhost:8080", Address: "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed"}
+			},
+			errMsg: "remote signer",
+		},
+		{
+			name: "both legacy hd paths",
+			modify: func(c *CLIConfig) {
+				c.Mnemonic = "test"
+				c.SequencerHDPath = hdPath
+				c.L2OutputHDPath = hdPath
+			},
+			errMsg: "cannot specify both deprecated flags",
+		},
+		{
+			name: "conflicting hd paths",
+			modify: func(c *CLIConfig) {
+				c.Mnemonic = "test"
+				c.HDPath = hdPath
+				c.SequencerHDPath = "m/44'/60'/0'/0/2"
+			},
+			errMsg: "conflicting HD paths",
+		},
+		{
+			name:   "hd path without mnemonic",
+			modify: func(c *CLIConfig) { c.HDPath = hdPath },
+			errMsg: "only be used together with a mnemonic",
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			cfg := validCLIConfig()
+			test.modify(&cfg)
+			err := cfg.Check()
+			if test.errMsg == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, test.errMsg)
+			}
+		})
+	}
+}
+
+func TestCLIConfigCheckAggregatesErrors(t *testing.T) {
+	cfg := validCLIConfig()
+	cfg.L1RPCURL = "localhost:8545"
+	cfg.NumConfirmations = 0
+	cfg.ReceiptQueryInterval = 2 * cfg.ResubmissionTimeout
+	cfg.PrivateKey = "0xabcd"
+
+	err := cfg.Check()
+	require.ErrorContains(t, err, "L1 RPC")
+	require.ErrorContains(t, err, "NumConfirmations must not be 0")
+	require.ErrorContains(t, err, "ReceiptQueryInterval")
+	require.ErrorContains(t, err, "private key must be 32 hex encoded bytes")
+	require.NotContains(t, err.Error(), "abcd")
+}
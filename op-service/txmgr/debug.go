@@ -0,0 +1,168 @@
+package txmgr
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// DebugNamespace is the RPC namespace of the [DebugAPI].
+const DebugNamespace = "debug"
+
+// InflightTx describes an in-flight send, i.e. a transaction together with its
+// fee bumped replacements.
+type InflightTx struct {
+	To       *common.Address `json:"to"`
+	Nonce    hexutil.Uint64  `json:"nonce"`
+	DataSize int             `json:"dataSize"`
+	State    string          `json:"state"`
+	// TxHashes are the hashes of all published transactions, latest last.
+	TxHashes  []common.Hash `json:"txHashes"`
+	GasTipCap *hexutil.Big  `json:"gasTipCap"`
+	GasFeeCap *hexutil.Big  `json:"gasFeeCap"`
+	Started   time.Time     `json:"started"`
+	// NextResubmission is when the transaction is resubmitted next, with bumped
+	// fees if necessary, unless it is mined before.
+	NextResubmission time.Time `json:"nextResubmission"`
+
+	// Publishes is the number of publications accepted by L1.
+	Publishes     uint64        `json:"publishes"`
+	MinedTxHashes []common.Hash `json:"minedTxHashes,omitempty"`
+	LastPublish   *time.Time    `json:"lastPublish,omitempty"`
+	// MempoolDeadline is when the send is aborted if no publication succeeded.
+	MempoolDeadline time.Time `json:"mempoolDeadline"`
+	// Error is why L1 rejected the transaction for good, if it did.
+	Error string `json:"error,omitempty"`
+}
+
+// sendProgress tracks an in-flight send for the [DebugAPI].
+type sendProgress struct {
+	state   *SendState
+	started time.Time
+
+	mu               sync.Mutex
+	tx               *types.Transaction
+	hashes           []common.Hash
+	nextResubmission time.Time
+}
+
+// published records the latest published transaction.
+func (p *sendProgress) published(tx *types.Transaction) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.tx = tx
+	if hash := tx.Hash(); len(p.hashes) == 0 || p.hashes[len(p.hashes)-1] != hash {
+		p.hashes = append(p.hashes, hash)
+	}
+}
+
+// scheduled records when the next resubmission is due.
+func (p *sendProgress) scheduled(next time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.nextResubmission = next
+}
+
+func (p *sendProgress) snapshot() InflightTx {
+	state := p.state.Snapshot()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	tx := InflightTx{
+		To:               p.tx.To(),
+		Nonce:            hexutil.Uint64(p.tx.Nonce()),
+		DataSize:         len(p.tx.Data()),
+		State:            state.State.String(),
+		TxHashes:         append([]common.Hash(nil), p.hashes...),
+		GasTipCap:        (*hexutil.Big)(p.tx.GasTipCap()),
+		GasFeeCap:        (*hexutil.Big)(p.tx.GasFeeCap()),
+		Started:          p.started,
+		NextResubmission: p.nextResubmission,
+		Publishes:        state.SuccessfulPublishes,
+		MinedTxHashes:    state.MinedTxs,
+		MempoolDeadline:  state.MempoolDeadline,
+	}
+	if !state.LastPublish.IsZero() {
+		tx.LastPublish = &state.LastPublish
+	}
+	if state.FatalError != nil {
+		tx.Error = state.FatalError.Error()
+	}
+	return tx
+}
+
+func (m *SimpleTxManager) trackProgress(tx *types.Transaction, state *SendState) *sendProgress {
+	p := &sendProgress{state: state, started: time.Now()}
+	p.published(tx)
+	m.inflightLock.Lock()
+	defer m.inflightLock.Unlock()
+	if m.progress == nil {
+		m.progress = make(map[*sendProgress]struct{})
+	}
+	m.progress[p] = struct{}{}
+	return p
+}
+
+func (m *SimpleTxManager) untrackProgress(p *sendProgress) {
+	m.inflightLock.Lock()
+	delete(m.progress, p)
+	m.inflightLock.Unlock()
+	m.recordSendState()
+}
+
+// recordSendState records the send state of the in-flight send with the lowest
+// nonce, as later sends cannot be mined before it.
+func (m *SimpleTxManager) recordSendState() {
+	var oldest *sendProgress
+	var nonce uint64
+	m.inflightLock.Lock()
+	for p := range m.progress {
+		p.mu.Lock()
+		n := p.tx.Nonce()
+		p.mu.Unlock()
+		if oldest == nil || n < nonce {
+			oldest, nonce = p, n
+		}
+	}
+	m.inflightLock.Unlock()
+
+	if oldest == nil {
+		m.metr.RecordSendState(0, 0, 0, 0)
+		return
+	}
+	s := oldest.state.Snapshot()
+	var sinceLastPublish time.Duration
+	if !s.LastPublish.IsZero() {
+		sinceLastPublish = time.Since(s.LastPublish)
+	}
+	m.metr.RecordSendState(s.SuccessfulPublishes, len(s.MinedTxs), sinceLastPublish, time.Until(s.MempoolDeadline))
+}
+
+// InflightTxs returns all in-flight sends, ordered by nonce.
+func (m *SimpleTxManager) InflightTxs() []InflightTx {
+	m.inflightLock.Lock()
+	txs := make([]InflightTx, 0, len(m.progress))
+	for p := range m.progress {
+		txs = append(txs, p.snapshot())
+	}
+	m.inflightLock.Unlock()
+	sort.Slice(txs, func(i, j int) bool { return txs[i].Nonce < txs[j].Nonce })
+	return txs
+}
+
+// DebugAPI lets operators inspect the in-flight sends of a transaction manager.
+type DebugAPI struct {
+	m *SimpleTxManager
+}
+
+func NewDebugAPI(m *SimpleTxManager) *DebugAPI {
+	return &DebugAPI{m: m}
+}
+
+func (a *DebugAPI) InflightTxs(_ context.Context) ([]InflightTx, error) {
+	return a.m.InflightTxs(), nil
+}
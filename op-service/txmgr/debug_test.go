@@ -0,0 +1,53 @@
+package txmgr
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInflightTxs(t *testing.T) {
+	t.Parallel()
+
+	h := newTestHarness(t)
+	gasTipCap, gasFeeCap := h.gasPricer.sample()
+	tx := types.NewTx(&types.DynamicFeeTx{
+		Nonce:     3,
+		GasTipCap: gasTipCap,
+		GasFeeCap: gasFeeCap,
+		Data:      []byte{1, 2, 3},
+	})
+	// never mined
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, _ = h.mgr.sendTx(ctx, tx, 0)
+	}()
+
+	api := NewDebugAPI(h.mgr)
+	var txs []InflightTx
+	require.Eventually(t, func() bool {
+		txs, _ = api.InflightTxs(context.Background())
+		return len(txs) == 1 && txs[0].State == TxStatePublished.String()
+	}, 5*time.Second, 10*time.Millisecond)
+	require.EqualValues(t, 3, txs[0].Nonce)
+	require.Equal(t, 3, txs[0].DataSize)
+	require.Equal(t, tx.Hash(), txs[0].TxHashes[0])
+	require.Equal(t, gasFeeCap, txs[0].GasFeeCap.ToInt())
+	require.True(t, txs[0].NextResubmission.After(txs[0].Started))
+	require.EqualValues(t, 1, txs[0].Publishes)
+	require.NotNil(t, txs[0].LastPublish)
+	require.Empty(t, txs[0].Error)
+
+	cancel()
+	<-done
+	require.Empty(t, h.mgr.InflightTxs())
+}
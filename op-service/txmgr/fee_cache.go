@@ -0,0 +1,57 @@
+package txmgr
+
+import (
+	"math/big"
+	"sync"
+	"time"
+)
+
+// feeCache caches the suggested gas tip cap and basefee for a short time, so
+// that a burst of sends doesn't query the L1 endpoint for every transaction.
+// The fees are dropped once they are older than the TTL, or as soon as a block
+// after the one they were suggested at is observed.
+type feeCache struct {
+	mu  sync.Mutex
+	ttl time.Duration
+	now func() time.Time
+
+	tip     *big.Int
+	basefee *big.Int
+	head    uint64
+	expiry  time.Time
+}
+
+func newFeeCache(ttl time.Duration) *feeCache {
+	return &feeCache{
+		ttl: ttl,
+		now: time.Now,
+	}
+}
+
+// Get returns the cached fees, if they are still current.
+func (c *feeCache) Get() (tip, basefee *big.Int, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.tip == nil || !c.now().Before(c.expiry) {
+		return nil, nil, false
+	}
+	return c.tip, c.basefee, true
+}
+
+// Set caches the fees suggested at the given head block number.
+func (c *feeCache) Set(tip, basefee *big.Int, head uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.tip, c.basefee, c.head = tip, basefee, head
+	c.expiry = c.now().Add(c.ttl)
+}
+
+// ObserveHead drops the cached fees if a newer block than the one they were
+// suggested at has been observed.
+func (c *feeCache) ObserveHead(head uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.tip != nil && head > c.head {
+		c.tip, c.basefee = nil, nil
+	}
+}
@@ -0,0 +1,62 @@
+package txmgr
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeeCache(t *testing.T) {
+	now := time.Unix(1000, 0)
+	c := newFeeCache(time.Second)
+	c.now = func() time.Time { return now }
+
+	_, _, ok := c.Get()
+	require.False(t, ok, "empty cache")
+
+	c.Set(big.NewInt(1), big.NewInt(2), 10)
+	tip, basefee, ok := c.Get()
+	require.True(t, ok)
+	require.Equal(t, big.NewInt(1), tip)
+	require.Equal(t, big.NewInt(2), basefee)
+
+	now = now.Add(time.Second)
+	_, _, ok = c.Get()
+	require.False(t, ok, "expired")
+
+	c.Set(big.NewInt(1), big.NewInt(2), 10)
+	c.ObserveHead(10)
+	_, _, ok = c.Get()
+	require.True(t, ok, "same head")
+	c.ObserveHead(11)
+	_, _, ok = c.Get()
+	require.False(t, ok, "new head")
+}
+
+// TestTxMgr_CraftTxFeeCache asserts that transactions crafted in quick
+// succession reuse the suggested fees if the fee cache is enabled.
+func TestTxMgr_CraftTxFeeCache(t *testing.T) {
+	t.Parallel()
+	h := newTestHarness(t)
+	h.mgr.feeCache = newFeeCache(time.Hour)
+	candidate := h.createTxCandidate()
+
+	first, err := h.mgr.craftTx(context.Background(), candidate)
+	require.NoError(t, err)
+	second, err := h.mgr.craftTx(context.Background(), candidate)
+	require.NoError(t, err)
+	require.Equal(t, first.GasTipCap(), second.GasTipCap())
+	require.Equal(t, first.GasFeeCap(), second.GasFeeCap())
+	require.Equal(t, int64(1), h.gasPricer.epoch, "fees should only be suggested once")
+
+	// fee bumps always use fresh fees, and refresh the cache
+	h.mgr.increaseGasPrice(context.Background(), second)
+	third, err := h.mgr.craftTx(context.Background(), candidate)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), h.gasPricer.epoch)
+	gasTipCap, _ := h.gasPricer.feesForEpoch(2)
+	require.Equal(t, gasTipCap, third.GasTipCap())
+}
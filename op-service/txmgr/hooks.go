@@ -0,0 +1,84 @@
+package txmgr
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Hooks lets deployments plug custom policies into the SimpleTxManager, like extra
+// validation, external approval of large fees or custom metrics. Embed [NoopHooks]
+// to implement only some of the hooks.
+type Hooks interface {
+	// BeforeCraft is called with the candidate of every send, before its
+	// transaction is crafted. It may modify the candidate, e.g. to adjust its
+	// gas limit. Returning an error aborts the send.
+	BeforeCraft(ctx context.Context, candidate *TxCandidate) error
+	// BeforeSign is called with every unsigned transaction, including fee bumps.
+	// Returning an error prevents the transaction from being signed. For a fee bump,
+	// the previous transaction is then resubmitted instead.
+	BeforeSign(ctx context.Context, tx *types.Transaction) error
+	// BeforePublish is called with every signed transaction before it is first
+	// published. Returning an error for the initial transaction aborts the send; for
+	// a fee bump, the previous transaction is resubmitted instead.
+	BeforePublish(ctx context.Context, tx *types.Transaction) error
+	// AfterConfirm is called with the receipt of every confirmed transaction.
+	AfterConfirm(ctx context.Context, receipt *types.Receipt)
+	// AfterFailure is called with the candidate and the error of every send that
+	// failed, including sends that were aborted or timed out, so ctx may be done.
+	AfterFailure(ctx context.Context, candidate TxCandidate, err error)
+}
+
+// NoopHooks implements [Hooks] without any policy.
+type NoopHooks struct{}
+
+func (NoopHooks) BeforeCraft(context.Context, *TxCandidate) error         { return nil }
+func (NoopHooks) BeforeSign(context.Context, *types.Transaction) error    { return nil }
+func (NoopHooks) BeforePublish(context.Context, *types.Transaction) error { return nil }
+func (NoopHooks) AfterConfirm(context.Context, *types.Receipt)            {}
+func (NoopHooks) AfterFailure(context.Context, TxCandidate, error)        {}
+
+// AddHooks registers hooks, which are called in registration order. It must not
+// be called concurrently with Send.
+func (m *SimpleTxManager) AddHooks(hooks ...Hooks) {
+	m.hooks = append(m.hooks, hooks...)
+}
+
+func (m *SimpleTxManager) beforeCraft(ctx context.Context, candidate *TxCandidate) error {
+	for _, h := range m.hooks {
+		if err := h.BeforeCraft(ctx, candidate); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *SimpleTxManager) beforeSign(ctx context.Context, tx *types.Transaction) error {
+	for _, h := range m.hooks {
+		if err := h.BeforeSign(ctx, tx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *SimpleTxManager) beforePublish(ctx context.Context, tx *types.Transaction) error {
+	for _, h := range m.hooks {
+		if err := h.BeforePublish(ctx, tx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *SimpleTxManager) afterConfirm(ctx context.Context, receipt *types.Receipt) {
+	for _, h := range m.hooks {
+		h.AfterConfirm(ctx, receipt)
+	}
+}
+
+func (m *SimpleTxManager) afterFailure(ctx context.Context, candidate TxCandidate, err error) {
+	for _, h := range m.hooks {
+		h.AfterFailure(ctx, candidate, err)
+	}
+}
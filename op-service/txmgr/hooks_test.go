@@ -0,0 +1,116 @@
+package txmgr
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingHooks struct {
+	NoopHooks
+	publishErr error
+	signed     int
+	published  int
+	confirmed  []*types.Receipt
+	gasLimit   uint64
+	failures   []error
+}
+
+func (h *recordingHooks) BeforeCraft(_ context.Context, candidate *TxCandidate) error {
+	if h.gasLimit != 0 {
+		candidate.GasLimit = h.gasLimit
+	}
+	return nil
+}
+
+func (h *recordingHooks) BeforeSign(context.Context, *types.Transaction) error {
+	h.signed++
+	return nil
+}
+
+func (h *recordingHooks) BeforePublish(context.Context, *types.Transaction) error {
+	h.published++
+	return h.publishErr
+}
+
+func (h *recordingHooks) AfterConfirm(_ context.Context, receipt *types.Receipt) {
+	h.confirmed = append(h.confirmed, receipt)
+}
+
+func (h *recordingHooks) AfterFailure(_ context.Context, _ TxCandidate, err error) {
+	h.failures = append(h.failures, err)
+}
+
+// TestHooks asserts that registered hooks are called while sending, and that a
+// rejection by BeforePublish aborts the send.
+func TestHooks(t *testing.T) {
+	t.Parallel()
+
+	h := newTestHarness(t)
+	hooks := &recordingHooks{}
+	h.mgr.AddHooks(hooks)
+
+	gasPricer := newGasPricer(1)
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		if gasPricer.shouldMine(tx.GasFeeCap()) {
+			txHash := tx.Hash()
+			h.backend.mine(&txHash, tx.GasFeeCap())
+		}
+		return nil
+	})
+	gasTipCap, gasFeeCap := gasPricer.sample()
+	tx := types.NewTx(&types.DynamicFeeTx{
+		GasTipCap: gasTipCap,
+		GasFeeCap: gasFeeCap,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	receipt, err := h.mgr.sendTx(ctx, tx, 0)
+	require.NoError(t, err)
+	require.Equal(t, 1, hooks.published)
+	require.Equal(t, []*types.Receipt{receipt}, hooks.confirmed)
+
+	_, err = h.mgr.craftTx(ctx, h.createTxCandidate())
+	require.NoError(t, err)
+	require.Equal(t, 1, hooks.signed)
+
+	rejection := errors.New("fee too large")
+	hooks.publishErr = rejection
+	_, err = h.mgr.sendTx(ctx, tx, 0)
+	require.ErrorIs(t, err, rejection)
+}
+
+// TestHooksSend asserts that BeforeCraft can modify the candidate of a send, and
+// that AfterFailure is called with the error of a failed send.
+func TestHooksSend(t *testing.T) {
+	t.Parallel()
+
+	h := newTestHarness(t)
+	hooks := &recordingHooks{gasLimit: 21_000}
+	h.mgr.AddHooks(hooks)
+
+	var gasLimit uint64
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		gasLimit = tx.Gas()
+		txHash := tx.Hash()
+		h.backend.mine(&txHash, tx.GasFeeCap())
+		return nil
+	})
+
+	_, err := h.mgr.Send(context.Background(), h.createTxCandidate())
+	require.NoError(t, err)
+	require.Equal(t, hooks.gasLimit, gasLimit)
+	require.Empty(t, hooks.failures)
+
+	h.backend.balance = big.NewInt(1)
+	_, err = h.mgr.Send(context.Background(), h.createTxCandidate())
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+	require.Len(t, hooks.failures, 1)
+	require.ErrorIs(t, hooks.failures[0], ErrInsufficientFunds)
+}
@@ -0,0 +1,280 @@
+package txmgr
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// journalCompactSlack is how many records the journal file may have beyond
+// twice the live entries, before it is compacted.
+const journalCompactSlack = 64
+
+// journal persists the published transactions of in-flight sends to a file, so
+// that they are accounted for after a restart. Sends are identified by the nonce
+// of their transactions, as callers don't generally craft the same data again,
+// e.g. the batcher picks a random channel ID for every channel. After a restart,
+// a send of a candidate that matches a journaled transaction resumes it, and a
+// new transaction that takes the nonce of a journaled one is priced to replace
+// it in the mempool.
+//
+// The file is an append-only log of JSON records. It is compacted when opened,
+// and whenever it grows well beyond the live entries. Entries with nonces below
+// the account nonce are pruned, as their transactions can't be included anymore.
+type journal struct {
+	path string
+
+	mu      sync.Mutex
+	f       *os.File
+	entries map[uint64]*journalEntry
+	// records is the number of records in the file
+	records int
+}
+
+type journalEntry struct {
+	// tx is the latest published transaction with the nonce.
+	tx *types.Transaction
+	// hashes are the hashes of all published transactions with the nonce, as any
+	// of them may have been included.
+	hashes []common.Hash
+}
+
+// journalRecord is a change to the journal entries. Exactly one field is set.
+type journalRecord struct {
+	// Tx records a published transaction.
+	Tx hexutil.Bytes `json:"tx,omitempty"`
+	// Replaced are the hashes of earlier transactions with the nonce of Tx. It
+	// is only set in compacted records.
+	Replaced []common.Hash `json:"replaced,omitempty"`
+	// Remove drops the entry with the nonce.
+	Remove *hexutil.Uint64 `json:"remove,omitempty"`
+	// PruneBelow drops the entries with lower nonces.
+	PruneBelow *hexutil.Uint64 `json:"prune_below,omitempty"`
+}
+
+// openJournal loads the journal at path, or starts an empty one if the file
+// doesn't exist yet. A torn last record, e.g. from a crash while writing it, is
+// ignored.
+func openJournal(path string) (*journal, error) {
+	j := &journal{
+		path:    path,
+		entries: make(map[uint64]*journalEntry),
+	}
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read tx journal: %w", err)
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	for {
+		var r journalRecord
+		if err := dec.Decode(&r); errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to decode tx journal %s: %w", path, err)
+		}
+		if err := j.apply(r); err != nil {
+			return nil, fmt.Errorf("invalid tx journal %s: %w", path, err)
+		}
+	}
+	if err := j.compact(); err != nil {
+		return nil, err
+	}
+	return j, nil
+}
+
+func (j *journal) apply(r journalRecord) error {
+	switch {
+	case r.Tx != nil:
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalBinary(r.Tx); err != nil {
+			return err
+		}
+		e, ok := j.entries[tx.Nonce()]
+		if !ok {
+			e = &journalEntry{}
+			j.entries[tx.Nonce()] = e
+		}
+		for _, h := range append(r.Replaced, tx.Hash()) {
+			if !containsHash(e.hashes, h) {
+				e.hashes = append(e.hashes, h)
+			}
+		}
+		e.tx = tx
+	case r.Remove != nil:
+		delete(j.entries, uint64(*r.Remove))
+	case r.PruneBelow != nil:
+		for nonce := range j.entries {
+			if nonce < uint64(*r.PruneBelow) {
+				delete(j.entries, nonce)
+			}
+		}
+	}
+	return nil
+}
+
+func containsHash(hashes []common.Hash, hash common.Hash) bool {
+	for _, h := range hashes {
+		if h == hash {
+			return true
+		}
+	}
+	return false
+}
+
+// Get returns the latest journaled transaction with the nonce, and the hashes of
+// all transactions with it. ok is false if there is none.
+func (j *journal) Get(nonce uint64) (tx *types.Transaction, hashes []common.Hash, ok bool) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	e, ok := j.entries[nonce]
+	if !ok {
+		return nil, nil, false
+	}
+	return e.tx, append([]common.Hash(nil), e.hashes...), true
+}
+
+// Find returns the journaled transaction with the given recipient and data, like
+// Get. If there are several, the one with the lowest nonce is returned.
+func (j *journal) Find(to *common.Address, data []byte) (tx *types.Transaction, hashes []common.Hash, ok bool) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	var found *journalEntry
+	for _, e := range j.entries {
+		if (to == nil) != (e.tx.To() == nil) || (to != nil && *to != *e.tx.To()) || !bytes.Equal(data, e.tx.Data()) {
+			continue
+		}
+		if found == nil || e.tx.Nonce() < found.tx.Nonce() {
+			found = e
+		}
+	}
+	if found == nil {
+		return nil, nil, false
+	}
+	return found.tx, append([]common.Hash(nil), found.hashes...), true
+}
+
+// Add records a published transaction.
+func (j *journal) Add(tx *types.Transaction) error {
+	data, err := tx.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if e, ok := j.entries[tx.Nonce()]; ok && e.tx.Hash() == tx.Hash() {
+		return nil
+	}
+	return j.append(journalRecord{Tx: data})
+}
+
+// Remove drops the entry with the nonce.
+func (j *journal) Remove(nonce uint64) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if _, ok := j.entries[nonce]; !ok {
+		return nil
+	}
+	n := hexutil.Uint64(nonce)
+	return j.append(journalRecord{Remove: &n})
+}
+
+// Prune drops the entries with nonces below the given one.
+func (j *journal) Prune(below uint64) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	for nonce := range j.entries {
+		if nonce < below {
+			n := hexutil.Uint64(below)
+			return j.append(journalRecord{PruneBelow: &n})
+		}
+	}
+	return nil
+}
+
+// Count returns the number of journaled nonces in the range [from, to).
+func (j *journal) Count(from, to uint64) int {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	n := 0
+	for nonce := range j.entries {
+		if nonce >= from && nonce < to {
+			n++
+		}
+	}
+	return n
+}
+
+// Len returns the number of journaled nonces.
+func (j *journal) Len() int {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return len(j.entries)
+}
+
+// append applies the record and writes it to the file.
+func (j *journal) append(r journalRecord) error {
+	if err := j.apply(r); err != nil {
+		return err
+	}
+	if j.records > 2*len(j.entries)+journalCompactSlack {
+		return j.compact()
+	}
+	line, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	if _, err := j.f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write tx journal: %w", err)
+	}
+	j.records++
+	return nil
+}
+
+// compact rewrites the file with one record per entry. It writes a temporary file
+// first, so that a crash while writing doesn't corrupt the journal.
+func (j *journal) compact() error {
+	var buf bytes.Buffer
+	for _, e := range j.entries {
+		data, err := e.tx.MarshalBinary()
+		if err != nil {
+			return err
+		}
+		line, err := json.Marshal(journalRecord{Tx: data, Replaced: e.hashes[:len(e.hashes)-1]})
+		if err != nil {
+			return err
+		}
+		buf.Write(append(line, '\n'))
+	}
+	tmp := j.path + ".tmp"
+	if err := os.WriteFile(tmp, buf.Bytes(), 0o600); err != nil {
+		return fmt.Errorf("failed to write tx journal: %w", err)
+	}
+	if err := os.Rename(tmp, j.path); err != nil {
+		return fmt.Errorf("failed to replace tx journal: %w", err)
+	}
+	if j.f != nil {
+		_ = j.f.Close()
+	}
+	f, err := os.OpenFile(j.path, os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open tx journal: %w", err)
+	}
+	j.f = f
+	j.records = len(j.entries)
+	return nil
+}
+
+// Close closes the journal file.
+func (j *journal) Close() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.f.Close()
+}
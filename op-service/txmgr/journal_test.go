@@ -0,0 +1,239 @@
+package txmgr
+
+import (
+	"context"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJournal(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal.json")
+	j, err := openJournal(path)
+	require.NoError(t, err)
+
+	to := common.Address{0x42}
+	_, _, ok := j.Get(3)
+	require.False(t, ok)
+
+	tx := types.NewTx(&types.DynamicFeeTx{Nonce: 3, To: &to, Data: []byte{1, 2, 3}, GasFeeCap: big.NewInt(10)})
+	bumped := types.NewTx(&types.DynamicFeeTx{Nonce: 3, To: &to, Data: []byte{1, 2, 3}, GasFeeCap: big.NewInt(20)})
+	other := types.NewTx(&types.DynamicFeeTx{Nonce: 5, To: &to, Data: []byte{4}})
+	require.NoError(t, j.Add(tx))
+	require.NoError(t, j.Add(tx))
+	require.NoError(t, j.Add(bumped))
+	require.NoError(t, j.Add(other))
+	require.NoError(t, j.Close())
+
+	// the journal survives reopening
+	j, err = openJournal(path)
+	require.NoError(t, err)
+	latest, hashes, ok := j.Get(3)
+	require.True(t, ok)
+	require.Equal(t, bumped.Hash(), latest.Hash())
+	require.Equal(t, []common.Hash{tx.Hash(), bumped.Hash()}, hashes)
+	found, _, ok := j.Find(&to, []byte{4})
+	require.True(t, ok)
+	require.Equal(t, other.Hash(), found.Hash())
+	_, _, ok = j.Find(&to, []byte{5})
+	require.False(t, ok)
+
+	require.NoError(t, j.Remove(5))
+	require.NoError(t, j.Prune(4))
+	require.NoError(t, j.Close())
+	j, err = openJournal(path)
+	require.NoError(t, err)
+	require.Zero(t, j.Len())
+	require.NoError(t, j.Close())
+}
+
+// TestJournalBounded asserts that the journal file doesn't grow with the number
+// of journaled transactions, but only with the live entries.
+func TestJournalBounded(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal.json")
+	j, err := openJournal(path)
+	require.NoError(t, err)
+	defer j.Close()
+
+	var size int64
+	for i := uint64(0); i < 10*journalCompactSlack; i++ {
+		require.NoError(t, j.Add(types.NewTx(&types.DynamicFeeTx{Nonce: i, Data: []byte{1, 2, 3}})))
+		require.NoError(t, j.Prune(i))
+		fi, err := os.Stat(path)
+		require.NoError(t, err)
+		if i == journalCompactSlack {
+			size = fi.Size()
+		}
+		if size != 0 {
+			require.LessOrEqual(t, fi.Size(), 2*size)
+		}
+	}
+	require.Equal(t, 1, j.Len())
+}
+
+func TestJournalTornRecord(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal.json")
+	j, err := openJournal(path)
+	require.NoError(t, err)
+	tx := types.NewTx(&types.DynamicFeeTx{Nonce: 1})
+	require.NoError(t, j.Add(tx))
+	require.NoError(t, j.Close())
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o600)
+	require.NoError(t, err)
+	_, err = f.WriteString(`{"tx":"0x02f8`)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	j, err = openJournal(path)
+	require.NoError(t, err)
+	defer j.Close()
+	_, _, ok := j.Get(1)
+	require.True(t, ok)
+	require.Equal(t, 1, j.Len())
+}
+
+// newJournaledHarness returns a test harness with a journal that contains a
+// transaction of the harness' tx candidate with the given nonce.
+func newJournaledHarness(t *testing.T, nonce uint64) (*testHarness, *types.Transaction) {
+	h := newTestHarness(t)
+	j, err := openJournal(filepath.Join(t.TempDir(), "journal.json"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = j.Close() })
+	h.mgr.journal = j
+
+	candidate := h.createTxCandidate()
+	tx := types.NewTx(&types.DynamicFeeTx{
+		Nonce:     nonce,
+		To:        candidate.To,
+		Data:      candidate.TxData,
+		Gas:       candidate.GasLimit,
+		GasTipCap: big.NewInt(1000),
+		GasFeeCap: big.NewInt(10000),
+	})
+	require.NoError(t, j.Add(tx))
+	return h, tx
+}
+
+// TestTxMgrResumesJournaledTx asserts that a send of a candidate with a journaled
+// transaction publishes that transaction again, instead of crafting a new one.
+func TestTxMgrResumesJournaledTx(t *testing.T) {
+	t.Parallel()
+
+	h, tx := newJournaledHarness(t, 0)
+	var published []common.Hash
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		txHash := tx.Hash()
+		published = append(published, txHash)
+		h.backend.mine(&txHash, tx.GasFeeCap())
+		return nil
+	})
+
+	candidate := h.createTxCandidate()
+	receipt, err := h.mgr.Send(context.Background(), candidate)
+	require.NoError(t, err)
+	require.Equal(t, tx.Hash(), receipt.TxHash)
+	require.Equal(t, []common.Hash{tx.Hash()}, published)
+
+	_, _, ok := h.mgr.journal.Get(tx.Nonce())
+	require.False(t, ok, "confirmed send should be removed from the journal")
+}
+
+// TestTxMgrResumesMinedJournaledTx asserts that a send of a candidate with an
+// included journaled transaction only waits for its confirmation.
+func TestTxMgrResumesMinedJournaledTx(t *testing.T) {
+	t.Parallel()
+
+	h, tx := newJournaledHarness(t, 0)
+	txHash := tx.Hash()
+	h.backend.mine(&txHash, tx.GasFeeCap())
+	var published bool
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		published = true
+		return nil
+	})
+
+	receipt, err := h.mgr.Send(context.Background(), h.createTxCandidate())
+	require.NoError(t, err)
+	require.Equal(t, txHash, receipt.TxHash)
+	require.False(t, published)
+}
+
+// TestTxMgrDropsStaleJournaledTx asserts that a journaled transaction whose nonce
+// is not the next one to use is replaced by a newly crafted transaction.
+func TestTxMgrDropsStaleJournaledTx(t *testing.T) {
+	t.Parallel()
+
+	h, tx := newJournaledHarness(t, 5)
+	var published []*types.Transaction
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		published = append(published, tx)
+		txHash := tx.Hash()
+		h.backend.mine(&txHash, tx.GasFeeCap())
+		return nil
+	})
+
+	receipt, err := h.mgr.Send(context.Background(), h.createTxCandidate())
+	require.NoError(t, err)
+	require.Len(t, published, 1)
+	require.NotEqual(t, tx.Hash(), receipt.TxHash)
+	require.Zero(t, published[0].Nonce())
+}
+
+// TestTxMgrReplacesJournaledTxAfterRestart asserts that after a restart, a send
+// of freshly built candidate data, like a batcher frame of a new channel, takes
+// over the nonce of the journaled transaction, priced to replace it in the
+// mempool, and that the journal entry is dropped once it's confirmed.
+func TestTxMgrReplacesJournaledTxAfterRestart(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "journal.json")
+	j, err := openJournal(path)
+	require.NoError(t, err)
+	to := common.Address{0x42}
+	old := types.NewTx(&types.DynamicFeeTx{
+		To:        &to,
+		Data:      []byte("frame of the old channel"),
+		Gas:       21000,
+		GasTipCap: big.NewInt(1000),
+		GasFeeCap: big.NewInt(10000),
+	})
+	require.NoError(t, j.Add(old))
+	require.NoError(t, j.Close())
+
+	// restart
+	j, err = openJournal(path)
+	require.NoError(t, err)
+	h := newTestHarness(t)
+	h.mgr.journal = j
+	var published []*types.Transaction
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		published = append(published, tx)
+		txHash := tx.Hash()
+		h.backend.mine(&txHash, tx.GasFeeCap())
+		return nil
+	})
+
+	candidate := h.createTxCandidate()
+	candidate.TxData = []byte("frame of a new channel")
+	receipt, err := h.mgr.Send(context.Background(), candidate)
+	require.NoError(t, err)
+	require.Len(t, published, 1)
+	tx := published[0]
+	require.Equal(t, tx.Hash(), receipt.TxHash)
+	require.Equal(t, old.Nonce(), tx.Nonce())
+	require.Equal(t, candidate.TxData, tx.Data())
+	require.GreaterOrEqual(t, tx.GasTipCap().Cmp(calcThresholdValue(old.GasTipCap())), 0)
+	require.GreaterOrEqual(t, tx.GasFeeCap().Cmp(calcThresholdValue(old.GasFeeCap())), 0)
+
+	require.NoError(t, h.mgr.Close(context.Background()))
+	j, err = openJournal(path)
+	require.NoError(t, err)
+	defer j.Close()
+	require.Zero(t, j.Len(), "confirmed send should be removed from the journal")
+}
@@ -1,13 +1,24 @@
 package metrics
 
-import "github.com/ethereum/go-ethereum/core/types"
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
 
 type NoopTxMetrics struct{}
 
-func (*NoopTxMetrics) RecordNonce(uint64)                {}
-func (*NoopTxMetrics) RecordPendingTx(int64)             {}
-func (*NoopTxMetrics) RecordGasBumpCount(int)            {}
-func (*NoopTxMetrics) RecordTxConfirmationLatency(int64) {}
-func (*NoopTxMetrics) TxConfirmed(*types.Receipt)        {}
-func (*NoopTxMetrics) TxPublished(string)                {}
-func (*NoopTxMetrics) RPCError()                         {}
+func (*NoopTxMetrics) RecordNonce(uint64)                                        {}
+func (*NoopTxMetrics) RecordPendingTx(int64)                                     {}
+func (*NoopTxMetrics) RecordGasBumpCount(int)                                    {}
+func (*NoopTxMetrics) RecordTxConfirmationLatency(int64)                         {}
+func (*NoopTxMetrics) TxConfirmed(*types.Receipt)                                {}
+func (*NoopTxMetrics) TxPublished(string)                                        {}
+func (*NoopTxMetrics) MaxTxFeeExceeded()                                         {}
+func (*NoopTxMetrics) RPCError()                                                 {}
+func (*NoopTxMetrics) RecordCircuitOpen(bool)                                    {}
+func (*NoopTxMetrics) RecordShedLevel(int)                                       {}
+func (*NoopTxMetrics) RPCRouted(string, string)                                  {}
+func (*NoopTxMetrics) TxBroadcast(string, bool)                                  {}
+func (*NoopTxMetrics) RecordTxStateTransition(string, string, string, bool)      {}
+func (*NoopTxMetrics) RecordSendState(uint64, int, time.Duration, time.Duration) {}
@@ -1,6 +1,8 @@
 package metrics
 
 import (
+	"time"
+
 	"github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/params"
@@ -16,6 +18,13 @@ type TxMetricer interface {
 	TxConfirmed(*types.Receipt)
 	TxPublished(string)
 	RPCError()
+	MaxTxFeeExceeded()
+	RecordShedLevel(int)
+	RecordCircuitOpen(open bool)
+	RPCRouted(kind string, endpoint string)
+	TxBroadcast(endpoint string, success bool)
+	RecordTxStateTransition(txmgr, from, to string, final bool)
+	RecordSendState(publishes uint64, minedTxs int, sinceLastPublish, untilMempoolDeadline time.Duration)
 }
 
 type TxMetrics struct {
@@ -30,6 +39,17 @@ type TxMetrics struct {
 	publishEvent       metrics.Event
 	confirmEvent       metrics.EventVec
 	rpcError           prometheus.Counter
+	maxTxFeeExceeded   prometheus.Counter
+	shedLevel          prometheus.Gauge
+	circuitOpen        prometheus.Gauge
+	rpcRoutes          *prometheus.CounterVec
+	txBroadcasts       *prometheus.CounterVec
+	txStates           *prometheus.GaugeVec
+	txsFinished        *prometheus.CounterVec
+	sendPublishes      prometheus.Gauge
+	sendMinedTxs       prometheus.Gauge
+	sendLastPublish    prometheus.Gauge
+	sendMempoolTimeout prometheus.Gauge
 }
 
 func receiptStatusString(receipt *types.Receipt) string {
@@ -104,9 +124,81 @@ func MakeTxMetrics(ns string, factory metrics.Factory) TxMetrics {
 			Help:      "Temporary: Count of RPC errors (like timeouts) that have occurred",
 			Subsystem: "txmgr",
 		}),
+		maxTxFeeExceeded: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "max_tx_fee_exceeded_count",
+			Help:      "Count of sends aborted because a transaction or fee bump would exceed the max tx fee",
+			Subsystem: "txmgr",
+		}),
+		shedLevel: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "shed_level",
+			Help:      "Current load shedding level due to L1 RPC rate limiting, 0 if no load is shed",
+			Subsystem: "txmgr",
+		}),
+		circuitOpen: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "circuit_open",
+			Help:      "1 while the L1 circuit breaker is open because of consecutive L1 RPC failures, 0 otherwise",
+			Subsystem: "txmgr",
+		}),
+		rpcRoutes: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "rpc_routes_total",
+			Help:      "Count of L1 RPC calls routed to each endpoint, by kind of call (read or write)",
+			Subsystem: "txmgr",
+		}, []string{"kind", "endpoint"}),
+		txBroadcasts: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "tx_broadcast_total",
+			Help:      "Count of transactions broadcast through each L1 endpoint, by result (success or error)",
+			Subsystem: "txmgr",
+		}, []string{"endpoint", "result"}),
+		txStates: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "tx_states",
+			Help:      "Number of in-flight sends per tx manager and lifecycle state (crafted, published or mined)",
+			Subsystem: "txmgr",
+		}, []string{"txmgr", "state"}),
+		txsFinished: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "tx_finished_total",
+			Help:      "Count of finished sends per tx manager and final lifecycle state (confirmed, failed, expired or aborted)",
+			Subsystem: "txmgr",
+		}, []string{"txmgr", "state"}),
+		sendPublishes: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "send_publishes",
+			Help:      "Number of successful publications of the oldest in-flight send",
+			Subsystem: "txmgr",
+		}),
+		sendMinedTxs: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "send_mined_txs",
+			Help:      "Number of mined transactions of the oldest in-flight send, awaiting confirmation",
+			Subsystem: "txmgr",
+		}),
+		sendLastPublish: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "send_since_last_publish_seconds",
+			Help:      "Time since the last successful publication of the oldest in-flight send, 0 if there was none",
+			Subsystem: "txmgr",
+		}),
+		sendMempoolTimeout: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "send_mempool_deadline_seconds",
+			Help:      "Time until the oldest in-flight send is aborted unless a publication succeeds, negative once passed",
+			Subsystem: "txmgr",
+		}),
 	}
 }
 
+// PersistCounters makes the fee counter continue from, and persist to, the given store.
+// It must be called before any fees are recorded.
+func (t *TxMetrics) PersistCounters(store *metrics.CounterStore) {
+	t.txFees = store.Counter("txmgr_tx_fee_gwei_total", t.txFees)
+}
+
 func (t *TxMetrics) RecordNonce(nonce uint64) {
 	t.currentNonce.Set(float64(nonce))
 }
@@ -144,3 +236,55 @@ func (t *TxMetrics) TxPublished(errString string) {
 func (t *TxMetrics) RPCError() {
 	t.rpcError.Inc()
 }
+
+func (t *TxMetrics) MaxTxFeeExceeded() {
+	t.maxTxFeeExceeded.Inc()
+}
+
+func (t *TxMetrics) RecordShedLevel(level int) {
+	t.shedLevel.Set(float64(level))
+}
+
+func (t *TxMetrics) RecordCircuitOpen(open bool) {
+	if open {
+		t.circuitOpen.Set(1)
+	} else {
+		t.circuitOpen.Set(0)
+	}
+}
+
+func (t *TxMetrics) RPCRouted(kind string, endpoint string) {
+	t.rpcRoutes.WithLabelValues(kind, endpoint).Inc()
+}
+
+func (t *TxMetrics) TxBroadcast(endpoint string, success bool) {
+	result := "success"
+	if !success {
+		result = "error"
+	}
+	t.txBroadcasts.WithLabelValues(endpoint, result).Inc()
+}
+
+// RecordTxStateTransition records the transition of a send of the named tx
+// manager between lifecycle states. from is empty for new sends. The states are
+// labeled by tx manager rather than by candidate label, as the latter is
+// unbounded, e.g. the batcher labels candidates with their channel ID.
+func (t *TxMetrics) RecordTxStateTransition(txmgr, from, to string, final bool) {
+	if from != "" {
+		t.txStates.WithLabelValues(txmgr, from).Dec()
+	}
+	if final {
+		t.txsFinished.WithLabelValues(txmgr, to).Inc()
+	} else {
+		t.txStates.WithLabelValues(txmgr, to).Inc()
+	}
+}
+
+// RecordSendState records the state of the oldest in-flight send, which the
+// decision to abort it is based on. All values are zero if no send is in flight.
+func (t *TxMetrics) RecordSendState(publishes uint64, minedTxs int, sinceLastPublish, untilMempoolDeadline time.Duration) {
+	t.sendPublishes.Set(float64(publishes))
+	t.sendMinedTxs.Set(float64(minedTxs))
+	t.sendLastPublish.Set(sinceLastPublish.Seconds())
+	t.sendMempoolTimeout.Set(untilMempoolDeadline.Seconds())
+}
@@ -19,6 +19,34 @@ type TxManager struct {
 	mock.Mock
 }
 
+// Abort provides a mock function with given fields: label
+func (_m *TxManager) Abort(label string) int {
+	ret := _m.Called(label)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(string) int); ok {
+		r0 = rf(label)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	return r0
+}
+
+// Close provides a mock function with given fields: ctx
+func (_m *TxManager) Close(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // From provides a mock function with given fields:
 func (_m *TxManager) From() common.Address {
 	ret := _m.Called()
@@ -0,0 +1,325 @@
+package txmgr
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/op-service/txmgr/metrics"
+)
+
+const (
+	// endpointEWMAWeight is the weight of the latest observation in the moving
+	// averages of endpoint latency and error rate.
+	endpointEWMAWeight = 0.2
+	// maxHealthyErrorRate is the error rate above which an endpoint is not routed to,
+	// unless all endpoints are unhealthy.
+	maxHealthyErrorRate = 0.5
+	// latencyHysteresis is the fraction by which another endpoint must be faster
+	// than the current read endpoint to switch to it.
+	latencyHysteresis = 0.2
+	// errorRateHysteresis is by how much the error rate of another endpoint must
+	// be lower than that of the current write endpoint to switch to it.
+	errorRateHysteresis = 0.1
+	// probeInterval is every how many reads one is sent to another endpoint than
+	// the routed one, so that the measurements of all endpoints stay current.
+	probeInterval = 50
+)
+
+const (
+	routeRead  = "read"
+	routeWrite = "write"
+)
+
+// endpoint is a backend together with its measured latency and error rate.
+type endpoint struct {
+	name    string
+	backend ETHBackend
+
+	mu        sync.Mutex
+	latency   time.Duration
+	errorRate float64
+}
+
+// observe updates the moving averages with the outcome of a call. Only transport
+// failures count as errors, JSON-RPC errors are valid responses of a healthy node.
+func (e *endpoint) observe(d time.Duration, err error) {
+	if errors.Is(err, context.Canceled) {
+		return
+	}
+	var rpcErr rpc.Error
+	failed := err != nil && !errors.As(err, &rpcErr)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if failed {
+		e.errorRate += endpointEWMAWeight * (1 - e.errorRate)
+		return
+	}
+	e.errorRate -= endpointEWMAWeight * e.errorRate
+	if e.latency == 0 {
+		e.latency = d
+	} else {
+		e.latency += time.Duration(endpointEWMAWeight * float64(d-e.latency))
+	}
+}
+
+func (e *endpoint) stats() (time.Duration, float64) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.latency, e.errorRate
+}
+
+// MultiBackend is an [ETHBackend] that spreads calls across several endpoints of
+// the same chain. It continuously measures the latency and error rate of every
+// endpoint, routing reads to the fastest healthy endpoint and transaction
+// submission to the most reliable one. To avoid flapping, it only switches when
+// another endpoint is better by a margin.
+type MultiBackend struct {
+	// RoundRobin spreads reads evenly across all healthy endpoints instead of
+	// routing them to the fastest one, and pins writes to the first endpoint.
+	// This scales the read volume, while the primary endpoint stays in charge
+	// of the account's pending state.
+	RoundRobin bool
+	// Broadcast submits transactions through all endpoints concurrently, so that
+	// a single endpoint's mempool or connectivity problems don't delay inclusion.
+	Broadcast bool
+
+	endpoints []*endpoint
+	metr      metrics.TxMetricer
+
+	mu    sync.Mutex
+	read  int
+	write int
+	reads uint64
+}
+
+var _ ETHBackend = (*MultiBackend)(nil)
+
+// NewMultiBackend creates a MultiBackend. The first backend is preferred until
+// the others have proven to be better. Endpoints are named by their index in
+// metrics, so that URLs with API keys don't leak.
+func NewMultiBackend(backends ...ETHBackend) *MultiBackend {
+	endpoints := make([]*endpoint, len(backends))
+	for i, b := range backends {
+		endpoints[i] = &endpoint{name: strconv.Itoa(i), backend: b}
+	}
+	return &MultiBackend{
+		endpoints: endpoints,
+		metr:      &metrics.NoopTxMetrics{},
+	}
+}
+
+// route picks the endpoint for the given kind of call.
+func (m *MultiBackend) route(kind string) *endpoint {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var e *endpoint
+	if m.RoundRobin {
+		e = m.roundRobin(kind)
+	} else if kind == routeWrite {
+		m.write = m.mostReliable(m.write)
+		e = m.endpoints[m.write]
+	} else {
+		m.read = m.fastestHealthy(m.read)
+		e = m.endpoints[m.read]
+		m.reads++
+		if n := uint64(len(m.endpoints)); n > 1 && m.reads%probeInterval == 0 {
+			e = m.endpoints[(uint64(m.read)+1+(m.reads/probeInterval)%(n-1))%n]
+		}
+	}
+	m.metr.RPCRouted(kind, e.name)
+	return e
+}
+
+// roundRobin returns the primary endpoint for writes, and the next healthy
+// endpoint in turn for reads. If none is healthy, all are used in turn.
+func (m *MultiBackend) roundRobin(kind string) *endpoint {
+	if kind == routeWrite {
+		return m.endpoints[0]
+	}
+	n := len(m.endpoints)
+	for i := 0; i < n; i++ {
+		m.read = (m.read + 1) % n
+		if _, errorRate := m.endpoints[m.read].stats(); errorRate <= maxHealthyErrorRate {
+			return m.endpoints[m.read]
+		}
+	}
+	m.read = (m.read + 1) % n
+	return m.endpoints[m.read]
+}
+
+// fastestHealthy returns the index of the endpoint to read from, given the current one.
+func (m *MultiBackend) fastestHealthy(current int) int {
+	best := -1
+	var bestLatency time.Duration
+	for i, e := range m.endpoints {
+		latency, errorRate := e.stats()
+		if errorRate > maxHealthyErrorRate {
+			continue
+		}
+		if best == -1 || latency < bestLatency {
+			best, bestLatency = i, latency
+		}
+	}
+	if best == -1 {
+		// all endpoints are unhealthy, fall back to the least bad one
+		return m.mostReliable(current)
+	}
+	curLatency, curErrorRate := m.endpoints[current].stats()
+	if curErrorRate <= maxHealthyErrorRate && float64(bestLatency) > float64(curLatency)*(1-latencyHysteresis) {
+		return current
+	}
+	return best
+}
+
+// mostReliable returns the index of the endpoint with the lowest error rate,
+// staying with the current one unless it is worse by a margin.
+func (m *MultiBackend) mostReliable(current int) int {
+	best := current
+	_, bestErrorRate := m.endpoints[current].stats()
+	for i, e := range m.endpoints {
+		if _, errorRate := e.stats(); errorRate < bestErrorRate-errorRateHysteresis {
+			best, bestErrorRate = i, errorRate
+		}
+	}
+	return best
+}
+
+// Close closes the connections of all endpoints.
+func (m *MultiBackend) Close() {
+	for _, e := range m.endpoints {
+		if c, ok := e.backend.(interface{ Close() }); ok {
+			c.Close()
+		}
+	}
+}
+
+func (m *MultiBackend) BlockNumber(ctx context.Context) (uint64, error) {
+	e := m.route(routeRead)
+	start := time.Now()
+	n, err := e.backend.BlockNumber(ctx)
+	e.observe(time.Since(start), err)
+	return n, err
+}
+
+func (m *MultiBackend) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	e := m.route(routeRead)
+	start := time.Now()
+	receipt, err := e.backend.TransactionReceipt(ctx, txHash)
+	if errors.Is(err, ethereum.NotFound) {
+		e.observe(time.Since(start), nil)
+	} else {
+		e.observe(time.Since(start), err)
+	}
+	return receipt, err
+}
+
+func (m *MultiBackend) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	e := m.route(routeWrite)
+	if m.Broadcast {
+		return m.broadcast(ctx, e, tx)
+	}
+	start := time.Now()
+	err := e.backend.SendTransaction(ctx, tx)
+	e.observe(time.Since(start), err)
+	return err
+}
+
+// broadcast sends the signed tx through all endpoints concurrently. As all of
+// them receive the same tx, it can only be included once. It succeeds if any
+// endpoint accepted the tx, otherwise it returns the error of the primary
+// endpoint, which the tx manager uses to decide how to proceed.
+func (m *MultiBackend) broadcast(ctx context.Context, primary *endpoint, tx *types.Transaction) error {
+	errs := make([]error, len(m.endpoints))
+	var wg sync.WaitGroup
+	for i, e := range m.endpoints {
+		wg.Add(1)
+		go func(i int, e *endpoint) {
+			defer wg.Done()
+			start := time.Now()
+			err := e.backend.SendTransaction(ctx, tx)
+			e.observe(time.Since(start), err)
+			m.metr.TxBroadcast(e.name, err == nil)
+			errs[i] = err
+		}(i, e)
+	}
+	wg.Wait()
+
+	var primaryErr error
+	for i, err := range errs {
+		if err == nil {
+			return nil
+		}
+		if m.endpoints[i] == primary {
+			primaryErr = err
+		}
+	}
+	return primaryErr
+}
+
+func (m *MultiBackend) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	e := m.route(routeRead)
+	start := time.Now()
+	header, err := e.backend.HeaderByNumber(ctx, number)
+	e.observe(time.Since(start), err)
+	return header, err
+}
+
+func (m *MultiBackend) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	e := m.route(routeRead)
+	start := time.Now()
+	tip, err := e.backend.SuggestGasTipCap(ctx)
+	e.observe(time.Since(start), err)
+	return tip, err
+}
+
+func (m *MultiBackend) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	e := m.route(routeRead)
+	start := time.Now()
+	nonce, err := e.backend.NonceAt(ctx, account, blockNumber)
+	e.observe(time.Since(start), err)
+	return nonce, err
+}
+
+// PendingNonceAt is routed like a write, as the pending state depends on the
+// mempool of the endpoint that transactions are submitted to.
+func (m *MultiBackend) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	e := m.route(routeWrite)
+	start := time.Now()
+	nonce, err := e.backend.PendingNonceAt(ctx, account)
+	e.observe(time.Since(start), err)
+	return nonce, err
+}
+
+func (m *MultiBackend) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	e := m.route(routeRead)
+	start := time.Now()
+	balance, err := e.backend.BalanceAt(ctx, account, blockNumber)
+	e.observe(time.Since(start), err)
+	return balance, err
+}
+
+func (m *MultiBackend) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	e := m.route(routeRead)
+	start := time.Now()
+	res, err := e.backend.CallContract(ctx, msg, blockNumber)
+	e.observe(time.Since(start), err)
+	return res, err
+}
+
+func (m *MultiBackend) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+	e := m.route(routeRead)
+	start := time.Now()
+	gas, err := e.backend.EstimateGas(ctx, msg)
+	e.observe(time.Since(start), err)
+	return gas, err
+}
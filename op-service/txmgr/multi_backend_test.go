@@ -0,0 +1,128 @@
+package txmgr
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+type testRPCError struct{}
+
+func (testRPCError) Error() string  { return "nonce too low" }
+func (testRPCError) ErrorCode() int { return -32000 }
+
+func setStats(e *endpoint, latency time.Duration, errorRate float64) {
+	e.latency, e.errorRate = latency, errorRate
+}
+
+func TestEndpointObserve(t *testing.T) {
+	e := &endpoint{}
+	e.observe(100*time.Millisecond, nil)
+	latency, errorRate := e.stats()
+	require.Equal(t, 100*time.Millisecond, latency)
+	require.Zero(t, errorRate)
+
+	e.observe(200*time.Millisecond, nil)
+	latency, _ = e.stats()
+	require.Equal(t, 120*time.Millisecond, latency)
+
+	e.observe(time.Second, testRPCError{})
+	_, errorRate = e.stats()
+	require.Zero(t, errorRate, "JSON-RPC errors are valid responses")
+
+	e.observe(time.Second, errors.New("connection refused"))
+	latency, errorRate = e.stats()
+	require.Equal(t, endpointEWMAWeight, errorRate)
+	require.Less(t, latency, time.Second, "failed calls don't count for latency")
+}
+
+func TestMultiBackendRouting(t *testing.T) {
+	m := NewMultiBackend(nil, nil, nil)
+	a, b, c := m.endpoints[0], m.endpoints[1], m.endpoints[2]
+	setStats(a, 100*time.Millisecond, 0)
+	setStats(b, 90*time.Millisecond, 0)
+	setStats(c, 200*time.Millisecond, 0)
+
+	require.Same(t, a, m.route(routeRead), "stays within hysteresis")
+	setStats(b, 50*time.Millisecond, 0)
+	require.Same(t, b, m.route(routeRead), "switches to a clearly faster endpoint")
+
+	setStats(b, 50*time.Millisecond, 0.6)
+	require.Same(t, a, m.route(routeRead), "avoids unhealthy endpoints")
+
+	setStats(a, 100*time.Millisecond, 0.7)
+	setStats(b, 50*time.Millisecond, 0.8)
+	setStats(c, 200*time.Millisecond, 0.9)
+	require.Same(t, a, m.route(routeRead), "falls back to the most reliable endpoint")
+
+	require.Same(t, a, m.route(routeWrite))
+	setStats(c, 200*time.Millisecond, 0.65)
+	require.Same(t, a, m.route(routeWrite), "stays within hysteresis")
+	setStats(c, 200*time.Millisecond, 0.1)
+	require.Same(t, c, m.route(routeWrite), "switches to a clearly more reliable endpoint")
+}
+
+func TestMultiBackendProbes(t *testing.T) {
+	m := NewMultiBackend(nil, nil, nil)
+	setStats(m.endpoints[0], time.Millisecond, 0)
+	setStats(m.endpoints[1], time.Second, 0)
+	setStats(m.endpoints[2], time.Second, 0)
+
+	routed := make(map[*endpoint]int)
+	for i := 0; i < 2*probeInterval; i++ {
+		routed[m.route(routeRead)]++
+	}
+	require.Equal(t, 2*probeInterval-2, routed[m.endpoints[0]])
+	require.Equal(t, 1, routed[m.endpoints[1]])
+	require.Equal(t, 1, routed[m.endpoints[2]])
+}
+
+type sendBackend struct {
+	ETHBackend
+	err  error
+	sent []*types.Transaction
+}
+
+func (b *sendBackend) SendTransaction(_ context.Context, tx *types.Transaction) error {
+	b.sent = append(b.sent, tx)
+	return b.err
+}
+
+func TestMultiBackendBroadcast(t *testing.T) {
+	primaryErr := errors.New("primary down")
+	a := &sendBackend{err: primaryErr}
+	b := &sendBackend{err: errors.New("secondary down")}
+	m := NewMultiBackend(a, b)
+	m.Broadcast = true
+	tx := types.NewTx(&types.DynamicFeeTx{Nonce: 1})
+
+	require.ErrorIs(t, m.SendTransaction(context.Background(), tx), primaryErr,
+		"returns the error of the primary endpoint if all fail")
+	require.Equal(t, []*types.Transaction{tx}, a.sent)
+	require.Equal(t, []*types.Transaction{tx}, b.sent)
+
+	b.err = nil
+	require.NoError(t, m.SendTransaction(context.Background(), tx), "succeeds if any endpoint accepts the tx")
+	require.Len(t, a.sent, 2)
+	require.Len(t, b.sent, 2)
+}
+
+func TestMultiBackendRoundRobin(t *testing.T) {
+	m := NewMultiBackend(nil, nil, nil)
+	m.RoundRobin = true
+	a, b, c := m.endpoints[0], m.endpoints[1], m.endpoints[2]
+	setStats(a, 10*time.Millisecond, 0.8)
+	setStats(b, 100*time.Millisecond, 0)
+	setStats(c, time.Second, 0)
+
+	var reads []*endpoint
+	for i := 0; i < 4; i++ {
+		reads = append(reads, m.route(routeRead))
+	}
+	require.Equal(t, []*endpoint{b, c, b, c}, reads, "skips the unhealthy endpoint")
+	require.Same(t, a, m.route(routeWrite), "writes are pinned to the primary")
+}
@@ -18,6 +18,15 @@ type TxReceipt[T any] struct {
 	Err error
 }
 
+// Priority is the priority class of a [TxCandidate] in a [Queue]. When the max
+// pending txs are reached, waiting sends of a higher priority start first.
+type Priority int
+
+const (
+	PriorityNormal Priority = iota
+	PriorityHigh
+)
+
 type Queue[T any] struct {
 	ctx        context.Context
 	txMgr      TxManager
@@ -25,6 +34,20 @@ type Queue[T any] struct {
 	groupLock  sync.Mutex
 	groupCtx   context.Context
 	group      *errgroup.Group
+
+	// active counts the sends started by the queue, to lower the concurrency
+	// while the tx manager sheds load.
+	activeLock sync.Mutex
+	activeCond *sync.Cond
+	active     uint64
+	// waiting counts the sends waiting for a slot, by priority.
+	waiting map[Priority]int
+}
+
+// loadShedding is implemented by tx managers that can shed load, like the
+// [SimpleTxManager]. Each shed level halves the max pending txs of a Queue.
+type loadShedding interface {
+	ShedLevel() int
 }
 
 // NewQueue creates a new transaction sending Queue, with the following parameters:
@@ -32,15 +55,14 @@ type Queue[T any] struct {
 //   - pendingChanged: called whenever a tx send starts or finishes. The
 //     number of currently pending txs is passed as a parameter.
 func NewQueue[T any](ctx context.Context, txMgr TxManager, maxPending uint64) *Queue[T] {
-	if maxPending > math.MaxInt {
-		// ensure we don't overflow as errgroup only accepts int; in reality this will never be an issue
-		maxPending = math.MaxInt
-	}
-	return &Queue[T]{
+	q := &Queue[T]{
 		ctx:        ctx,
 		txMgr:      txMgr,
 		maxPending: maxPending,
+		waiting:    make(map[Priority]int),
 	}
+	q.activeCond = sync.NewCond(&q.activeLock)
+	return q
 }
 
 // Wait waits for all pending txs to complete (or fail).
@@ -52,20 +74,24 @@ func (q *Queue[T]) Wait() {
 }
 
 // Send will wait until the number of pending txs is below the max pending,
-// and then send the next tx.
+// and then send the next tx. Sends waiting with a higher candidate priority
+// go first.
 //
 // The actual tx sending is non-blocking, with the receipt returned on the
 // provided receipt channel. If the channel is unbuffered, the goroutine is
 // blocked from completing until the channel is read from.
 func (q *Queue[T]) Send(id T, candidate TxCandidate, receiptCh chan TxReceipt[T]) {
+	// join the group before waiting for a slot, so that the send fails if a
+	// send it waited for fails
 	group, ctx := q.groupContext()
+	q.acquire(candidate.Priority, true)
 	group.Go(func() error {
 		return q.sendTx(ctx, id, candidate, receiptCh)
 	})
 }
 
 // TrySend sends the next tx, but only if the number of pending txs is below the
-// max pending.
+// max pending, and no send of a higher priority is waiting.
 //
 // Returns false if there is no room in the queue to send. Otherwise, the
 // transaction is queued and this method returns true.
@@ -74,14 +100,78 @@ func (q *Queue[T]) Send(id T, candidate TxCandidate, receiptCh chan TxReceipt[T]
 // provided receipt channel. If the channel is unbuffered, the goroutine is
 // blocked from completing until the channel is read from.
 func (q *Queue[T]) TrySend(id T, candidate TxCandidate, receiptCh chan TxReceipt[T]) bool {
+	if !q.acquire(candidate.Priority, false) {
+		return false
+	}
 	group, ctx := q.groupContext()
-	return group.TryGo(func() error {
+	group.Go(func() error {
 		return q.sendTx(ctx, id, candidate, receiptCh)
 	})
+	return true
+}
+
+// acquire reserves a slot for a send, below the max pending limit lowered by
+// load shedding. Slots go to waiting sends of the highest priority first. If
+// wait is false, it returns false instead of waiting for a free slot.
+func (q *Queue[T]) acquire(prio Priority, wait bool) bool {
+	q.activeLock.Lock()
+	defer q.activeLock.Unlock()
+	if !wait {
+		if q.active >= q.shedLimit() || q.waitingAbove(prio) {
+			return false
+		}
+		q.active++
+		return true
+	}
+	q.waiting[prio]++
+	for q.active >= q.shedLimit() || q.waitingAbove(prio) {
+		q.activeCond.Wait()
+	}
+	q.waiting[prio]--
+	q.active++
+	// lower priority sends may go next, if there are more free slots
+	q.activeCond.Broadcast()
+	return true
+}
+
+// waitingAbove returns whether any send of a higher priority than prio is waiting.
+func (q *Queue[T]) waitingAbove(prio Priority) bool {
+	for p, n := range q.waiting {
+		if p > prio && n > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (q *Queue[T]) release() {
+	q.activeLock.Lock()
+	defer q.activeLock.Unlock()
+	q.active--
+	q.activeCond.Broadcast()
+}
+
+// shedLimit returns the number of concurrent sends allowed at the current shed level.
+func (q *Queue[T]) shedLimit() uint64 {
+	if q.maxPending == 0 {
+		return math.MaxUint64
+	}
+	ls, ok := q.txMgr.(loadShedding)
+	if !ok {
+		return q.maxPending
+	}
+	limit := q.maxPending >> ls.ShedLevel()
+	if limit == 0 {
+		limit = 1
+	}
+	return limit
 }
 
 func (q *Queue[T]) sendTx(ctx context.Context, id T, candidate TxCandidate, receiptCh chan TxReceipt[T]) error {
 	receipt, err := q.txMgr.Send(ctx, candidate)
+	// free the slot before returning the receipt, so that the receiver can send
+	// the next tx right away
+	q.release()
 	receiptCh <- TxReceipt[T]{
 		ID:      id,
 		Receipt: receipt,
@@ -104,10 +194,8 @@ func (q *Queue[T]) groupContext() (*errgroup.Group, context.Context) {
 		if q.group != nil {
 			_ = q.group.Wait()
 		}
+		// the group isn't limited, as acquire enforces the max pending txs
 		q.group, q.groupCtx = errgroup.WithContext(q.ctx)
-		if q.maxPending > 0 {
-			q.group.SetLimit(int(q.maxPending))
-		}
 	}
 	return q.group, q.groupCtx
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"math/big"
+	"sync"
 	"testing"
 	"time"
 
@@ -237,3 +238,64 @@ func TestSend(t *testing.T) {
 		})
 	}
 }
+
+// blockingTxManager records the order of sends, which block until unblocked.
+type blockingTxManager struct {
+	TxManager
+	unblock chan struct{}
+
+	mu   sync.Mutex
+	sent []byte
+}
+
+func (m *blockingTxManager) Send(ctx context.Context, candidate TxCandidate) (*types.Receipt, error) {
+	m.mu.Lock()
+	m.sent = append(m.sent, candidate.TxData[0])
+	m.mu.Unlock()
+	<-m.unblock
+	return &types.Receipt{}, nil
+}
+
+func TestQueuePriority(t *testing.T) {
+	mgr := &blockingTxManager{unblock: make(chan struct{})}
+	queue := NewQueue[int](context.Background(), mgr, 1)
+	receiptCh := make(chan TxReceipt[int], 3)
+	candidate := func(i int, prio Priority) TxCandidate {
+		return TxCandidate{TxData: []byte{byte(i)}, Priority: prio}
+	}
+	waiting := func(prio Priority) int {
+		queue.activeLock.Lock()
+		defer queue.activeLock.Unlock()
+		return queue.waiting[prio]
+	}
+
+	queue.Send(0, candidate(0, PriorityNormal), receiptCh)
+	go queue.Send(1, candidate(1, PriorityNormal), receiptCh)
+	require.Eventually(t, func() bool { return waiting(PriorityNormal) == 1 }, 5*time.Second, 10*time.Millisecond)
+	go queue.Send(2, candidate(2, PriorityHigh), receiptCh)
+	require.Eventually(t, func() bool { return waiting(PriorityHigh) == 1 }, 5*time.Second, 10*time.Millisecond)
+	require.False(t, queue.TrySend(3, candidate(3, PriorityNormal), receiptCh))
+
+	close(mgr.unblock)
+	for i := 0; i < 3; i++ {
+		require.NoError(t, (<-receiptCh).Err)
+	}
+	queue.Wait()
+	require.Equal(t, []byte{0, 2, 1}, mgr.sent, "high priority send jumps the queue")
+}
+
+// TestQueueTrySendAfterReceipt asserts that there is room in the queue for the
+// next send as soon as the receipt of the previous one is received.
+func TestQueueTrySendAfterReceipt(t *testing.T) {
+	mgr := &blockingTxManager{unblock: make(chan struct{})}
+	close(mgr.unblock)
+	queue := NewQueue[int](context.Background(), mgr, 1)
+	receiptCh := make(chan TxReceipt[int])
+	for i := 0; i < 10; i++ {
+		require.True(t, queue.TrySend(i, TxCandidate{TxData: []byte{byte(i)}}, receiptCh), "send %d", i)
+		// let the send finish and block on the receipt channel
+		time.Sleep(5 * time.Millisecond)
+		require.NoError(t, (<-receiptCh).Err)
+	}
+	queue.Wait()
+}
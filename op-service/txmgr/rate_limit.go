@@ -0,0 +1,109 @@
+package txmgr
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"golang.org/x/time/rate"
+)
+
+// rateLimitedBackend is an [ETHBackend] that limits the rate of calls to the
+// backend with a token bucket, so that resubmission storms stay within the
+// request quota of L1 RPC providers. Calls wait for a token, or fail once their
+// context is done.
+type rateLimitedBackend struct {
+	backend ETHBackend
+	limiter *rate.Limiter
+}
+
+var _ ETHBackend = (*rateLimitedBackend)(nil)
+
+func newRateLimitedBackend(backend ETHBackend, limit float64, burst int) *rateLimitedBackend {
+	if burst < 1 {
+		burst = 1
+	}
+	return &rateLimitedBackend{
+		backend: backend,
+		limiter: rate.NewLimiter(rate.Limit(limit), burst),
+	}
+}
+
+// Close closes the wrapped backend, if it can be closed.
+func (b *rateLimitedBackend) Close() {
+	if c, ok := b.backend.(interface{ Close() }); ok {
+		c.Close()
+	}
+}
+
+func (b *rateLimitedBackend) BlockNumber(ctx context.Context) (uint64, error) {
+	if err := b.limiter.Wait(ctx); err != nil {
+		return 0, err
+	}
+	return b.backend.BlockNumber(ctx)
+}
+
+func (b *rateLimitedBackend) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	if err := b.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+	return b.backend.TransactionReceipt(ctx, txHash)
+}
+
+func (b *rateLimitedBackend) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	if err := b.limiter.Wait(ctx); err != nil {
+		return err
+	}
+	return b.backend.SendTransaction(ctx, tx)
+}
+
+func (b *rateLimitedBackend) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	if err := b.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+	return b.backend.HeaderByNumber(ctx, number)
+}
+
+func (b *rateLimitedBackend) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	if err := b.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+	return b.backend.SuggestGasTipCap(ctx)
+}
+
+func (b *rateLimitedBackend) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	if err := b.limiter.Wait(ctx); err != nil {
+		return 0, err
+	}
+	return b.backend.NonceAt(ctx, account, blockNumber)
+}
+
+func (b *rateLimitedBackend) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	if err := b.limiter.Wait(ctx); err != nil {
+		return 0, err
+	}
+	return b.backend.PendingNonceAt(ctx, account)
+}
+
+func (b *rateLimitedBackend) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	if err := b.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+	return b.backend.BalanceAt(ctx, account, blockNumber)
+}
+
+func (b *rateLimitedBackend) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+	if err := b.limiter.Wait(ctx); err != nil {
+		return 0, err
+	}
+	return b.backend.EstimateGas(ctx, msg)
+}
+
+func (b *rateLimitedBackend) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	if err := b.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+	return b.backend.CallContract(ctx, msg, blockNumber)
+}
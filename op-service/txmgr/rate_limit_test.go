@@ -0,0 +1,54 @@
+package txmgr
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr/metrics"
+)
+
+func TestRateLimitedBackend(t *testing.T) {
+	backend := &flakyBackend{}
+	b := newRateLimitedBackend(backend, 20, 1)
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		_, err := b.BlockNumber(context.Background())
+		require.NoError(t, err)
+	}
+	require.GreaterOrEqual(t, time.Since(start), 90*time.Millisecond, "calls beyond the burst wait for tokens")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := b.BlockNumber(ctx)
+	require.Error(t, err)
+	require.Equal(t, 3, backend.calls, "canceled call must not reach the backend")
+}
+
+// TestRateLimitedBackendOutsideBreaker asserts that calls throttled by the rate
+// limiter don't open the circuit breaker.
+func TestRateLimitedBackendOutsideBreaker(t *testing.T) {
+	backend := &flakyBackend{}
+	b, breaker := wrapBackend(Config{
+		Backend:                 backend,
+		L1RPCRateLimit:          1,
+		L1RPCRateBurst:          1,
+		CircuitBreakerThreshold: 1,
+		CircuitBreakerCooldown:  time.Minute,
+		NetworkTimeout:          time.Second,
+	}, testlog.Logger(t, log.LvlCrit), &metrics.NoopTxMetrics{})
+
+	_, err := b.BlockNumber(context.Background())
+	require.NoError(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = b.BlockNumber(ctx)
+	require.Error(t, err, "no token before the deadline")
+	require.Equal(t, 1, backend.calls)
+	require.NoError(t, breaker.allow(context.Background()), "throttled call must not open the breaker")
+}
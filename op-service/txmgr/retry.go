@@ -0,0 +1,47 @@
+package txmgr
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/backoff"
+)
+
+// Resubmission policies, see [RetryPolicy].
+const (
+	RetryPolicyFixed       = "fixed"
+	RetryPolicyExponential = "exponential"
+)
+
+// RetryPolicy decides how long to wait before resubmitting a transaction. The
+// attempt is the number of resubmissions so far. Any [backoff.Strategy] can be
+// used as a RetryPolicy.
+type RetryPolicy interface {
+	Duration(attempt int) time.Duration
+}
+
+var _ RetryPolicy = (backoff.Strategy)(nil)
+
+// exponentialRetry doubles the wait with every attempt, up to max, and adds up
+// to 10% of jitter so that the resubmissions of concurrent sends spread out.
+type exponentialRetry struct {
+	base, max time.Duration
+}
+
+// ExponentialRetryPolicy returns a RetryPolicy that starts at base and doubles
+// with every attempt, up to max, plus jitter.
+func ExponentialRetryPolicy(base, max time.Duration) RetryPolicy {
+	return &exponentialRetry{base: base, max: max}
+}
+
+func (e *exponentialRetry) Duration(attempt int) time.Duration {
+	d := e.max
+	// guard against overflows of the shift
+	if attempt < 32 && e.base<<attempt < e.max {
+		d = e.base << attempt
+	}
+	if jitter := int64(d / 10); jitter > 0 {
+		d += time.Duration(rand.Int63n(jitter))
+	}
+	return d
+}
@@ -0,0 +1,47 @@
+package txmgr
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExponentialRetryPolicy(t *testing.T) {
+	p := ExponentialRetryPolicy(time.Second, 10*time.Second)
+	for _, tc := range []struct {
+		attempt int
+		base    time.Duration
+	}{
+		{0, time.Second},
+		{1, 2 * time.Second},
+		{3, 8 * time.Second},
+		{4, 10 * time.Second},
+		{100, 10 * time.Second},
+	} {
+		d := p.Duration(tc.attempt)
+		require.GreaterOrEqual(t, d, tc.base, "attempt %d", tc.attempt)
+		require.Less(t, d, tc.base+tc.base/10, "attempt %d", tc.attempt)
+	}
+}
+
+func TestResubmissionTimeoutUsesRetryPolicy(t *testing.T) {
+	h := newTestHarness(t)
+	require.Equal(t, h.cfg.ResubmissionTimeout, h.mgr.resubmissionTimeout(5), "fixed without a policy")
+
+	h.mgr.cfg.RetryPolicy = ExponentialRetryPolicy(time.Millisecond, time.Hour)
+	require.GreaterOrEqual(t, h.mgr.resubmissionTimeout(5), 32*time.Millisecond)
+}
+
+func TestCLIConfigCheckResubmissionPolicy(t *testing.T) {
+	cfg := validCLIConfig()
+	cfg.ResubmissionPolicy = RetryPolicyExponential
+	cfg.ResubmissionTimeoutMax = cfg.ResubmissionTimeout / 2
+	require.ErrorContains(t, cfg.Check(), "ResubmissionTimeoutMax")
+
+	cfg.ResubmissionTimeoutMax = 10 * cfg.ResubmissionTimeout
+	require.NoError(t, cfg.Check())
+
+	cfg.ResubmissionPolicy = "linear"
+	require.ErrorContains(t, cfg.Check(), "unknown resubmission policy")
+}
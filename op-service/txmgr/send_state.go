@@ -1,14 +1,44 @@
 package txmgr
 
 import (
+	"fmt"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
 )
 
+// fatalSendErrors are rejections of a txn that resubmitting it, even with
+// higher fees, cannot resolve.
+var fatalSendErrors = []error{
+	core.ErrInsufficientFunds,
+	core.ErrIntrinsicGas,
+	core.ErrMaxInitCodeSizeExceeded,
+	core.ErrTipVeryHigh,
+	core.ErrFeeCapVeryHigh,
+	core.ErrSenderNoEOA,
+	types.ErrTxTypeNotSupported,
+	txpool.ErrInvalidSender,
+	txpool.ErrGasLimit,
+	txpool.ErrNegativeValue,
+	txpool.ErrOversizedData,
+}
+
+// isFatalSendError returns whether err is one of the fatalSendErrors. Errors are
+// matched by message, as they are received over RPC.
+func isFatalSendError(err error) bool {
+	for _, fatal := range fatalSendErrors {
+		if strings.Contains(err.Error(), fatal.Error()) {
+			return true
+		}
+	}
+	return false
+}
+
 // SendState tracks information about the publication state of a given txn. In
 // this context, a txn may correspond to multiple different txn hashes due to
 // varying gas prices, though we treat them all as the same logical txn. This
@@ -25,7 +55,13 @@ type SendState struct {
 
 	// Counts of the different types of errors
 	successFullPublishCount   uint64 // nil error => tx made it to the mempool
+	lastPublish               time.Time
 	safeAbortNonceTooLowCount uint64 // nonce too low error
+	fatalErr                  error  // first error that resubmitting can't resolve
+
+	state TxState
+	// onTransition is called for every state transition, with the lock held
+	onTransition func(from, to TxState)
 }
 
 // NewSendStateWithNow creates a new send state with the provided clock.
@@ -55,10 +91,20 @@ func (s *SendState) ProcessSendError(err error) {
 
 	// Record the type of error
 	switch {
-	case err == nil:
+	// an already known tx is in the mempool, e.g. a resumed journaled tx, or one
+	// whose earlier publication timed out after the node accepted it
+	case err == nil, strings.Contains(err.Error(), txpool.ErrAlreadyKnown.Error()):
 		s.successFullPublishCount++
+		s.lastPublish = s.now()
+		if s.state == TxStateCrafted {
+			s.transition(TxStatePublished)
+		}
 	case strings.Contains(err.Error(), core.ErrNonceTooLow.Error()):
 		s.nonceTooLowCount++
+	case isFatalSendError(err):
+		if s.fatalErr == nil {
+			s.fatalErr = err
+		}
 	}
 }
 
@@ -69,6 +115,7 @@ func (s *SendState) TxMined(txHash common.Hash) {
 	defer s.mu.Unlock()
 
 	s.minedTxs[txHash] = struct{}{}
+	s.transition(TxStateMined)
 }
 
 // TxMined records that the txn with txnHash has not been mined or has been
@@ -87,30 +134,125 @@ func (s *SendState) TxNotMined(txHash common.Hash) {
 	// observations.
 	if len(s.minedTxs) == 0 && wasMined {
 		s.nonceTooLowCount = 0
+		s.transition(TxStatePublished)
 	}
 }
 
+// State returns the current lifecycle state of the send.
+func (s *SendState) State() TxState {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.state
+}
+
+// Finish moves the send to the given final state. It returns false if the send
+// cannot move to that state, e.g. because it is already finished.
+func (s *SendState) Finish(state TxState) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return state.Final() && s.transition(state)
+}
+
+// transition moves to the given state if the transition is valid. Invalid
+// transitions, e.g. a late receipt of a finished send, are ignored.
+func (s *SendState) transition(to TxState) bool {
+	if !s.state.CanTransition(to) {
+		return false
+	}
+	if s.onTransition != nil {
+		s.onTransition(s.state, to)
+	}
+	s.state = to
+	return true
+}
+
 // ShouldAbortImmediately returns true if the txmgr should give up on trying a
 // given txn with the target nonce.
 // This occurs when the set of errors recorded indicates that no further progress can be made
 // on this transaction.
 func (s *SendState) ShouldAbortImmediately() bool {
+	return s.AbortReason() != nil
+}
+
+// AbortReason returns why the txmgr should give up on the txn, or nil if it
+// should not. The error wraps one of ErrInsufficientFunds, ErrRejected,
+// ErrNonceUsed or ErrNotInMempool, so that callers can tell the reasons apart.
+func (s *SendState) AbortReason() error {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
 	// Never abort if our latest sample reports having at least one mined txn.
 	if len(s.minedTxs) > 0 {
-		return false
+		return nil
 	}
 
+	// If all txns were rejected for good, resubmitting won't help
+	if err := s.fatalError(); errStringMatch(err, core.ErrInsufficientFunds) {
+		return fmt.Errorf("%w: %v", ErrInsufficientFunds, err)
+	} else if err != nil {
+		return fmt.Errorf("%w: %v", ErrRejected, err)
+	}
 	// If we have exceeded the nonce too low count, abort
-	if s.nonceTooLowCount >= s.safeAbortNonceTooLowCount ||
-		// If we have not published a transaction in the allotted time, abort
-		(s.successFullPublishCount == 0 && s.now().After(s.txInMempoolDeadline)) {
-		return true
+	if s.nonceTooLowCount >= s.safeAbortNonceTooLowCount {
+		return ErrNonceUsed
 	}
+	// If we have not published a transaction in the allotted time, abort
+	if s.successFullPublishCount == 0 && s.now().After(s.txInMempoolDeadline) {
+		return ErrNotInMempool
+	}
+	return nil
+}
 
-	return false
+// FatalError returns the error for which L1 rejected the txn for good, e.g.
+// because the sender cannot pay for it, or nil if there is none. Once a txn is
+// in the mempool, rejections of its fee bumps don't count, as the earlier txn
+// may still be mined.
+func (s *SendState) FatalError() error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.fatalError()
+}
+
+func (s *SendState) fatalError() error {
+	if s.successFullPublishCount > 0 {
+		return nil
+	}
+	return s.fatalErr
+}
+
+// SendStateSnapshot is the state that abort decisions of a [SendState] are based on.
+type SendStateSnapshot struct {
+	State TxState
+	// SuccessfulPublishes is the number of publications accepted by L1.
+	SuccessfulPublishes uint64
+	NonceTooLowCount    uint64
+	// MinedTxs are the hashes of the txns that were mined, awaiting confirmation.
+	MinedTxs []common.Hash
+	// LastPublish is the time of the latest successful publication, zero if there is none.
+	LastPublish time.Time
+	// MempoolDeadline is when the send is aborted unless a publication succeeded.
+	MempoolDeadline time.Time
+	FatalError      error
+}
+
+// Snapshot returns a copy of the current state.
+func (s *SendState) Snapshot() SendStateSnapshot {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	mined := make([]common.Hash, 0, len(s.minedTxs))
+	for h := range s.minedTxs {
+		mined = append(mined, h)
+	}
+	return SendStateSnapshot{
+		State:               s.state,
+		SuccessfulPublishes: s.successFullPublishCount,
+		NonceTooLowCount:    s.nonceTooLowCount,
+		MinedTxs:            mined,
+		LastPublish:         s.lastPublish,
+		MempoolDeadline:     s.txInMempoolDeadline,
+		FatalError:          s.fatalError(),
+	}
 }
 
 // IsWaitingForConfirmation returns true if we have at least one confirmation on
@@ -2,6 +2,7 @@ package txmgr_test
 
 import (
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -10,6 +11,8 @@ import (
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
 )
 
 var (
@@ -70,6 +73,7 @@ func TestSendStateAbortSafelyAfterNonceTooLowButNoTxMined(t *testing.T) {
 	require.False(t, sendState.ShouldAbortImmediately())
 	sendState.ProcessSendError(core.ErrNonceTooLow)
 	require.True(t, sendState.ShouldAbortImmediately())
+	require.ErrorIs(t, sendState.AbortReason(), txmgr.ErrNonceUsed)
 }
 
 // TestSendStateMiningTxCancelsAbort asserts that a tx getting mined after
@@ -180,6 +184,7 @@ func stepClock(step time.Duration) func() time.Time {
 func TestSendStateTimeoutAbort(t *testing.T) {
 	sendState := newSendStateWithTimeout(10*time.Millisecond, stepClock(20*time.Millisecond))
 	require.True(t, sendState.ShouldAbortImmediately(), "Should abort after timing out")
+	require.ErrorIs(t, sendState.AbortReason(), txmgr.ErrNotInMempool)
 }
 
 // TestSendStateNoTimeoutAbortIfPublishedTx ensure that this will not abort if there is
@@ -189,3 +194,151 @@ func TestSendStateNoTimeoutAbortIfPublishedTx(t *testing.T) {
 	sendState.ProcessSendError(nil)
 	require.False(t, sendState.ShouldAbortImmediately(), "Should not abort if published transcation successfully")
 }
+
+// TestSendStateAbortOnInsufficientFunds asserts that we abort right away if the
+// sender cannot pay for the tx, but not once an earlier tx made it to the mempool.
+func TestSendStateAbortOnInsufficientFunds(t *testing.T) {
+	sendState := newSendState()
+
+	sendState.ProcessSendError(fmt.Errorf("%w: address 0x01 have 1 want 2", core.ErrInsufficientFunds))
+	require.True(t, sendState.ShouldAbortImmediately())
+	require.ErrorIs(t, sendState.FatalError(), core.ErrInsufficientFunds)
+	require.ErrorIs(t, sendState.AbortReason(), txmgr.ErrInsufficientFunds)
+
+	sendState = newSendState()
+	sendState.ProcessSendError(nil)
+	sendState.ProcessSendError(core.ErrInsufficientFunds)
+	require.False(t, sendState.ShouldAbortImmediately())
+	require.NoError(t, sendState.FatalError())
+}
+
+// TestSendStateAbortOnFatalErrors asserts that only errors that resubmitting
+// cannot resolve make us abort right away.
+func TestSendStateAbortOnFatalErrors(t *testing.T) {
+	tests := []struct {
+		err   error
+		abort bool
+	}{
+		{core.ErrIntrinsicGas, true},
+		{txpool.ErrOversizedData, true},
+		{txpool.ErrGasLimit, true},
+		{txpool.ErrInvalidSender, true},
+		{types.ErrTxTypeNotSupported, true},
+		{txpool.ErrUnderpriced, false},
+		{txpool.ErrReplaceUnderpriced, false},
+		{txpool.ErrTxPoolOverflow, false},
+		{txpool.ErrAlreadyKnown, false},
+		{core.ErrNonceTooHigh, false},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.err.Error(), func(t *testing.T) {
+			sendState := newSendState()
+			// errors are received over RPC, without their original type
+			sendState.ProcessSendError(errors.New(test.err.Error()))
+			require.Equal(t, test.abort, sendState.ShouldAbortImmediately())
+			if test.abort {
+				require.EqualError(t, sendState.FatalError(), test.err.Error())
+				require.ErrorIs(t, sendState.AbortReason(), txmgr.ErrRejected)
+			} else {
+				require.NoError(t, sendState.FatalError())
+			}
+		})
+	}
+}
+
+// TestSendStateLifecycle asserts that the send state moves through the lifecycle
+// states, including back to published on a reorg, and ignores invalid transitions.
+func TestSendStateLifecycle(t *testing.T) {
+	sendState := newSendState()
+	require.Equal(t, txmgr.TxStateCrafted, sendState.State())
+
+	sendState.ProcessSendError(errors.New("unknown error"))
+	require.Equal(t, txmgr.TxStateCrafted, sendState.State())
+
+	sendState.ProcessSendError(nil)
+	require.Equal(t, txmgr.TxStatePublished, sendState.State())
+
+	sendState.TxMined(testHash)
+	require.Equal(t, txmgr.TxStateMined, sendState.State())
+
+	sendState.TxNotMined(testHash)
+	require.Equal(t, txmgr.TxStatePublished, sendState.State(), "reorged out")
+
+	require.False(t, sendState.Finish(txmgr.TxStateConfirmed), "cannot confirm an unmined tx")
+	sendState.TxMined(testHash)
+	require.True(t, sendState.Finish(txmgr.TxStateConfirmed))
+	require.Equal(t, txmgr.TxStateConfirmed, sendState.State())
+
+	require.False(t, sendState.Finish(txmgr.TxStateAborted), "final states are final")
+	sendState.TxNotMined(testHash)
+	require.Equal(t, txmgr.TxStateConfirmed, sendState.State())
+}
+
+// TestSendStateAlreadyKnown asserts that a tx rejected as already known counts
+// as published, as it is in the mempool.
+func TestSendStateAlreadyKnown(t *testing.T) {
+	sendState := newSendState()
+	sendState.ProcessSendError(fmt.Errorf("rpc error: %w", txpool.ErrAlreadyKnown))
+	require.Equal(t, txmgr.TxStatePublished, sendState.State())
+	require.Equal(t, uint64(1), sendState.Snapshot().SuccessfulPublishes)
+}
+
+// TestSendStateMinedWithoutPublish asserts that a send whose publication failed,
+// e.g. timed out after the node accepted its tx, is confirmed once the tx is
+// included, and not recorded as aborted afterwards.
+func TestSendStateMinedWithoutPublish(t *testing.T) {
+	sendState := newSendState()
+	sendState.ProcessSendError(errors.New("context deadline exceeded"))
+	require.Equal(t, txmgr.TxStateCrafted, sendState.State())
+
+	sendState.TxMined(testHash)
+	require.Equal(t, txmgr.TxStateMined, sendState.State())
+	require.True(t, sendState.Finish(txmgr.TxStateConfirmed))
+	require.False(t, sendState.Finish(txmgr.TxStateAborted))
+	require.Equal(t, txmgr.TxStateConfirmed, sendState.State())
+}
+
+// TestSendStateSnapshot asserts that the snapshot reflects the publications,
+// mined txs and rejections processed so far.
+func TestSendStateSnapshot(t *testing.T) {
+	var start time.Time
+	step := 20 * time.Millisecond
+	sendState := newSendStateWithTimeout(time.Second, stepClock(step))
+
+	snapshot := sendState.Snapshot()
+	require.Equal(t, txmgr.TxStateCrafted, snapshot.State)
+	require.Zero(t, snapshot.SuccessfulPublishes)
+	require.Empty(t, snapshot.MinedTxs)
+	require.True(t, snapshot.LastPublish.IsZero())
+	require.Equal(t, start.Add(step+time.Second), snapshot.MempoolDeadline)
+	require.Nil(t, snapshot.FatalError)
+
+	sendState.ProcessSendError(core.ErrInsufficientFunds)
+	require.ErrorIs(t, sendState.Snapshot().FatalError, core.ErrInsufficientFunds)
+
+	sendState.ProcessSendError(core.ErrNonceTooLow)
+	sendState.ProcessSendError(nil)
+	sendState.TxMined(testHash)
+	snapshot = sendState.Snapshot()
+	require.Equal(t, txmgr.TxStateMined, snapshot.State)
+	require.Equal(t, uint64(1), snapshot.SuccessfulPublishes)
+	require.Equal(t, uint64(1), snapshot.NonceTooLowCount)
+	require.Equal(t, []common.Hash{testHash}, snapshot.MinedTxs)
+	require.Equal(t, start.Add(2*step), snapshot.LastPublish)
+	require.Nil(t, snapshot.FatalError, "rejections don't count once published")
+}
+
+func TestTxStateTransitions(t *testing.T) {
+	for _, from := range []txmgr.TxState{txmgr.TxStateCrafted, txmgr.TxStatePublished, txmgr.TxStateMined} {
+		require.False(t, from.Final(), from.String())
+		for _, to := range []txmgr.TxState{txmgr.TxStateFailed, txmgr.TxStateExpired, txmgr.TxStateAborted} {
+			require.True(t, from.CanTransition(to), "%v -> %v", from, to)
+		}
+		require.False(t, from.CanTransition(txmgr.TxStateCrafted))
+	}
+	require.True(t, txmgr.TxStateCrafted.CanTransition(txmgr.TxStateMined))
+	require.False(t, txmgr.TxStateCrafted.CanTransition(txmgr.TxStateConfirmed))
+	require.False(t, txmgr.TxStatePublished.CanTransition(txmgr.TxStateConfirmed))
+	require.True(t, txmgr.TxStateConfirmed.Final())
+}
@@ -0,0 +1,140 @@
+package txmgr
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	// shedWindow is the period in which rate limited requests are counted.
+	shedWindow = time.Minute
+	// maxShedLevel caps load shedding at 1/8 of the concurrency and 8 times the
+	// resubmission timeout.
+	maxShedLevel = 3
+)
+
+// loadShedder adapts to rate limiting by the L1 RPC provider. Whenever the number
+// of rate limited requests within a shedWindow reaches the threshold, the shed
+// level increases by one. Each level halves the send concurrency of a [Queue] and
+// doubles the resubmission timeout. Every window without any rate limited request
+// decreases the level by one again.
+type loadShedder struct {
+	mu        sync.Mutex
+	threshold uint64
+	now       func() time.Time
+
+	level       int
+	count       uint64
+	limited     bool
+	windowStart time.Time
+}
+
+func newLoadShedder(threshold uint64) *loadShedder {
+	return &loadShedder{
+		threshold:   threshold,
+		now:         time.Now,
+		windowStart: time.Now(),
+	}
+}
+
+// Observe records the outcome of an RPC request and returns the current shed level.
+func (s *loadShedder) Observe(err error) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.advance()
+	if !isRateLimited(err) {
+		return s.level
+	}
+	s.limited = true
+	s.count++
+	if s.count >= s.threshold && s.level < maxShedLevel {
+		s.level++
+		s.count = 0
+	}
+	return s.level
+}
+
+// Level returns the current shed level.
+func (s *loadShedder) Level() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.advance()
+	return s.level
+}
+
+// advance moves to the current window, lowering the level for every completed
+// window without rate limiting.
+func (s *loadShedder) advance() {
+	windows := int(s.now().Sub(s.windowStart) / shedWindow)
+	if windows == 0 {
+		return
+	}
+	quiet := windows
+	if s.limited {
+		quiet--
+	}
+	if quiet > s.level {
+		quiet = s.level
+	}
+	s.level -= quiet
+	s.count = 0
+	s.limited = false
+	s.windowStart = s.windowStart.Add(time.Duration(windows) * shedWindow)
+}
+
+// isRateLimited returns whether err is a HTTP 429 Too Many Requests response, or
+// an error that a provider uses instead to signal rate limiting.
+func isRateLimited(err error) bool {
+	if err == nil {
+		return false
+	}
+	var httpErr rpc.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode == http.StatusTooManyRequests
+	}
+	// some providers return the HTTP status as JSON-RPC error code
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) && rpcErr.ErrorCode() == http.StatusTooManyRequests {
+		return true
+	}
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "too many requests") || strings.Contains(msg, "rate limit")
+}
+
+// ShedLevel returns the current load shedding level, 0 if no load is shed.
+func (m *SimpleTxManager) ShedLevel() int {
+	if m.shedder == nil {
+		return 0
+	}
+	level := m.shedder.Level()
+	m.metr.RecordShedLevel(level)
+	return level
+}
+
+// observeRPCError records an RPC error, for metrics and load shedding.
+func (m *SimpleTxManager) observeRPCError(err error) {
+	m.metr.RPCError()
+	if m.shedder == nil {
+		return
+	}
+	prev := m.shedder.Level()
+	if level := m.shedder.Observe(err); level > prev {
+		m.l.Warn("L1 RPC provider is rate limiting, shedding load", "level", level)
+		m.metr.RecordShedLevel(level)
+	}
+}
+
+// resubmissionTimeout returns the resubmission timeout of the given attempt,
+// scaled by the shed level.
+func (m *SimpleTxManager) resubmissionTimeout(attempt int) time.Duration {
+	timeout := m.cfg.ResubmissionTimeout
+	if m.cfg.RetryPolicy != nil {
+		timeout = m.cfg.RetryPolicy.Duration(attempt)
+	}
+	return timeout << m.ShedLevel()
+}
@@ -0,0 +1,56 @@
+package txmgr
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsRateLimited(t *testing.T) {
+	require.False(t, isRateLimited(nil))
+	require.False(t, isRateLimited(errors.New("connection refused")))
+	require.True(t, isRateLimited(fmt.Errorf("wrapped: %w", rpc.HTTPError{StatusCode: 429, Status: "429 Too Many Requests"})))
+	require.False(t, isRateLimited(rpc.HTTPError{StatusCode: 503, Status: "503 Service Unavailable"}))
+	require.True(t, isRateLimited(errors.New("Too Many Requests")))
+	require.True(t, isRateLimited(rateLimitRPCError{}))
+	require.True(t, isRateLimited(errors.New("daily request rate limit exceeded")))
+	require.False(t, isRateLimited(errors.New("transaction 0x4290af not found")), "status code only matches as such")
+	require.False(t, isRateLimited(rpc.HTTPError{StatusCode: 500, Status: "500 Internal Server Error", Body: []byte("block 4290")}))
+}
+
+type rateLimitRPCError struct{}
+
+func (rateLimitRPCError) Error() string  { return "compute units per second capacity exceeded" }
+func (rateLimitRPCError) ErrorCode() int { return 429 }
+
+func TestLoadShedder(t *testing.T) {
+	now := time.Unix(1000, 0)
+	s := newLoadShedder(2)
+	s.now = func() time.Time { return now }
+	s.windowStart = now
+
+	limited := rpc.HTTPError{StatusCode: 429}
+	require.Equal(t, 0, s.Observe(errors.New("timeout")))
+	require.Equal(t, 0, s.Observe(limited))
+	require.Equal(t, 1, s.Observe(limited), "threshold reached")
+	require.Equal(t, 1, s.Observe(limited))
+	require.Equal(t, 2, s.Observe(limited))
+
+	for i := 0; i < 10; i++ {
+		s.Observe(limited)
+	}
+	require.Equal(t, maxShedLevel, s.Level(), "level is capped")
+
+	// the window with rate limiting doesn't lower the level
+	now = now.Add(shedWindow)
+	require.Equal(t, maxShedLevel, s.Level())
+	// every quiet window lowers it by one
+	now = now.Add(shedWindow)
+	require.Equal(t, maxShedLevel-1, s.Level())
+	now = now.Add(10 * shedWindow)
+	require.Equal(t, 0, s.Level())
+}
@@ -0,0 +1,79 @@
+package txmgr
+
+// TxState is a state in the lifecycle of a send. All transactions of a send,
+// i.e. the original and its fee bumped replacements, share the same state.
+//
+//	Crafted -> Published <-> Mined -> Confirmed
+//
+// A crafted send may also move to mined directly, if its tx is included before
+// a publication of it succeeded, e.g. when the publication timed out after the
+// node accepted the tx.
+//
+// Any state that is not final can also move to one of the final states Failed,
+// Expired or Aborted.
+type TxState int
+
+const (
+	// TxStateCrafted is the state of a send whose tx was crafted, but not yet
+	// accepted by an L1 endpoint.
+	TxStateCrafted TxState = iota
+	// TxStatePublished is the state of a send with a tx in the mempool.
+	TxStatePublished
+	// TxStateMined is the state of a send with a tx included in a block, but
+	// waiting for enough confirmations. It moves back to published on reorgs.
+	TxStateMined
+	// TxStateConfirmed is the final state of a send with a confirmed tx.
+	TxStateConfirmed
+	// TxStateFailed is the final state of a send that could not make progress,
+	// e.g. because the nonce was used by another tx.
+	TxStateFailed
+	// TxStateExpired is the final state of a send that timed out.
+	TxStateExpired
+	// TxStateAborted is the final state of a send canceled by its caller.
+	TxStateAborted
+)
+
+func (s TxState) String() string {
+	switch s {
+	case TxStateCrafted:
+		return "crafted"
+	case TxStatePublished:
+		return "published"
+	case TxStateMined:
+		return "mined"
+	case TxStateConfirmed:
+		return "confirmed"
+	case TxStateFailed:
+		return "failed"
+	case TxStateExpired:
+		return "expired"
+	case TxStateAborted:
+		return "aborted"
+	default:
+		return "unknown"
+	}
+}
+
+// Final returns whether no transitions out of the state are possible.
+func (s TxState) Final() bool {
+	return s >= TxStateConfirmed
+}
+
+// CanTransition returns whether a send may move from state s to state to.
+func (s TxState) CanTransition(to TxState) bool {
+	if s.Final() || s == to {
+		return false
+	}
+	switch to {
+	case TxStateCrafted:
+		return false
+	case TxStatePublished:
+		return s == TxStateCrafted || s == TxStateMined
+	case TxStateMined:
+		return s == TxStateCrafted || s == TxStatePublished
+	case TxStateConfirmed:
+		return s == TxStateMined
+	default:
+		return to.Final()
+	}
+}
@@ -5,17 +5,20 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"sort"
 	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/txpool"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
 
 	"github.com/ethereum-optimism/optimism/op-service/txmgr/metrics"
 )
@@ -28,6 +31,48 @@ const priceBump int64 = 15
 var priceBumpPercent = big.NewInt(100 + priceBump)
 var oneHundred = big.NewInt(100)
 
+// ErrPoolBacklog is returned by Send if the sender has more foreign pending
+// transactions in the mempool than allowed by [Config.MaxPoolBacklog].
+var ErrPoolBacklog = errors.New("too many pending transactions of the sender in the mempool")
+
+// ErrAborted is returned by Send if the send was aborted with [TxManager.Abort].
+var ErrAborted = errors.New("transaction send aborted")
+
+// ErrInsufficientFunds is returned by Send if the sender cannot pay for the
+// transaction, either found before publishing it or reported by L1 endpoints.
+var ErrInsufficientFunds = errors.New("insufficient funds for transaction")
+
+// ErrRejected is returned by Send if L1 endpoints rejected the transaction for a
+// reason that resubmitting it cannot resolve, e.g. because its data is too large.
+var ErrRejected = errors.New("transaction rejected")
+
+// ErrNonceUsed is returned by Send if L1 endpoints keep reporting that the nonce
+// of the transaction is too low, i.e. another transaction with it was mined.
+var ErrNonceUsed = errors.New("nonce used by another transaction")
+
+// ErrNotInMempool is returned by Send if no publication of the transaction was
+// accepted within [Config.TxNotInMempoolTimeout].
+var ErrNotInMempool = errors.New("transaction did not make it to the mempool")
+
+// ErrIncludeByPassed is returned by Send if the transaction was not included
+// by the L1 block [TxCandidate.IncludeBy].
+var ErrIncludeByPassed = errors.New("transaction not included by the deadline block")
+
+// ErrSimulationFailed is returned by Send if [Config.SimulateTxs] is enabled and
+// the transaction reverts when simulated against the latest L1 state.
+var ErrSimulationFailed = errors.New("transaction simulation failed")
+
+// ErrMaxTxFee is returned by Send if the fee of the transaction, or of one of
+// its fee bumps, would exceed [Config.MaxTxFee].
+var ErrMaxTxFee = errors.New("transaction fee exceeds the maximum")
+
+// DefaultCloseTimeout is how long services wait for in-flight sends when
+// closing the transaction manager on shutdown.
+const DefaultCloseTimeout = 30 * time.Second
+
+// ErrClosed is returned by Send after the transaction manager was closed.
+var ErrClosed = errors.New("transaction manager closed")
+
 // TxManager is an interface that allows callers to reliably publish txs,
 // bumping the gas price if needed, and obtain the receipt of the resulting tx.
 //
@@ -44,6 +89,17 @@ type TxManager interface {
 	// From returns the sending address associated with the instance of the transaction manager.
 	// It is static for a single instance of a TxManager.
 	From() common.Address
+
+	// Abort stops publishing and resubmitting the transactions of all in-flight sends
+	// of candidates with the given label, which then return ErrAborted. Transactions
+	// that were already published may still be included. It returns the number of
+	// aborted sends.
+	Abort(label string) int
+
+	// Close stops accepting new sends, which then return ErrClosed, and waits for
+	// in-flight sends to finish. If ctx is done first, the remaining sends are
+	// canceled. Finally, it closes the L1 connections.
+	Close(ctx context.Context) error
 }
 
 // ETHBackend is the set of methods that the transaction manager uses to resubmit gas & determine
@@ -69,9 +125,15 @@ type ETHBackend interface {
 	NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error)
 	// PendingNonceAt returns the pending nonce.
 	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+	// BalanceAt returns the wei balance of the given account.
+	// The block number can be nil, in which case the balance is taken from the latest known block.
+	BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error)
 	// EstimateGas returns an estimate of the amount of gas needed to execute the given
 	// transaction against the current pending block.
 	EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error)
+	// CallContract executes the given call against the state of the given block.
+	// The block number can be nil, in which case the latest known block is used.
+	CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error)
 }
 
 // SimpleTxManager is a implementation of TxManager that performs linear fee
@@ -89,6 +151,29 @@ type SimpleTxManager struct {
 	nonceLock sync.RWMutex
 
 	pending atomic.Int64
+
+	hooks []Hooks
+
+	inflightLock sync.Mutex
+	inflight     map[string]map[*inflightSend]struct{}
+	progress     map[*sendProgress]struct{}
+
+	// closed is set by Close, guarded by inflightLock. Close cancels in-flight
+	// sends by closing the closing channel.
+	closed  bool
+	closing chan struct{}
+	sends   sync.WaitGroup
+
+	shedder  *loadShedder
+	feeCache *feeCache
+	journal  *journal
+	breaker  *circuitBreaker
+}
+
+// inflightSend tracks a labeled send, so that it can be aborted.
+type inflightSend struct {
+	cancel  context.CancelFunc
+	aborted atomic.Bool
 }
 
 // NewSimpleTxManager initializes a new SimpleTxManager with the passed Config.
@@ -98,16 +183,60 @@ func NewSimpleTxManager(name string, l log.Logger, m metrics.TxMetricer, cfg CLI
 		return nil, err
 	}
 
+	if mb, ok := conf.Backend.(*MultiBackend); ok {
+		mb.metr = m
+	}
+
+	var shedder *loadShedder
+	if conf.RateLimitThreshold > 0 {
+		shedder = newLoadShedder(conf.RateLimitThreshold)
+	}
+
+	var fees *feeCache
+	if conf.FeeCacheTTL > 0 {
+		fees = newFeeCache(conf.FeeCacheTTL)
+	}
+
+	var j *journal
+	if conf.JournalPath != "" {
+		if j, err = openJournal(conf.JournalPath); err != nil {
+			return nil, err
+		}
+	}
+
+	backend, breaker := wrapBackend(conf, l.New("service", name), m)
+
 	return &SimpleTxManager{
-		chainID: conf.ChainID,
-		name:    name,
-		cfg:     conf,
-		backend: conf.Backend,
-		l:       l.New("service", name),
-		metr:    m,
+		chainID:  conf.ChainID,
+		name:     name,
+		cfg:      conf,
+		backend:  backend,
+		l:        l.New("service", name),
+		metr:     m,
+		shedder:  shedder,
+		feeCache: fees,
+		journal:  j,
+		breaker:  breaker,
 	}, nil
 }
 
+// wrapBackend wraps the backend of conf in the circuit breaker and the rate
+// limiter, if enabled. The rate limiter goes outside of the breaker, so that
+// calls failing to get a token, e.g. because their context expires first, don't
+// count as failures of the endpoint.
+func wrapBackend(conf Config, l log.Logger, m metrics.TxMetricer) (ETHBackend, *circuitBreaker) {
+	backend := conf.Backend
+	var breaker *circuitBreaker
+	if conf.CircuitBreakerThreshold > 0 {
+		breaker = newCircuitBreaker(backend, conf.CircuitBreakerThreshold, conf.CircuitBreakerCooldown, conf.NetworkTimeout, l, m)
+		backend = breaker
+	}
+	if conf.L1RPCRateLimit > 0 {
+		backend = newRateLimitedBackend(backend, conf.L1RPCRateLimit, conf.L1RPCRateBurst)
+	}
+	return backend, breaker
+}
+
 func (m *SimpleTxManager) From() common.Address {
 	return m.cfg.From
 }
@@ -121,6 +250,22 @@ type TxCandidate struct {
 	To *common.Address
 	// GasLimit is the gas limit to be used in the constructed tx.
 	GasLimit uint64
+	// Label optionally groups candidates, so that their sends can be
+	// aborted together with [TxManager.Abort], e.g. all frames of a channel.
+	Label string
+	// Priority orders the candidate among the sends waiting for a [Queue]. It
+	// does not affect the fees paid.
+	Priority Priority
+	// IncludeBy is the last L1 block the tx may be included in, e.g. because its
+	// data is useless afterwards. Once L1 is at that block and the tx was not
+	// mined, the send fails with ErrIncludeByPassed instead of resubmitting. A
+	// published tx cannot be recalled though, so it may still be included.
+	// Zero means there is no deadline.
+	IncludeBy uint64
+	// Labels are attached to all log lines of the send, e.g. a channel ID and
+	// frame number, to trace it through the tx manager. Unlike Label, they don't
+	// group sends.
+	Labels map[string]string
 }
 
 // Send is used to publish a transaction with incrementally higher gas prices
@@ -133,15 +278,172 @@ type TxCandidate struct {
 //
 // NOTE: Send can be called concurrently, the nonce will be managed internally.
 func (m *SimpleTxManager) Send(ctx context.Context, candidate TxCandidate) (*types.Receipt, error) {
+	ctx, done, err := m.beginSend(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer done()
 	m.metr.RecordPendingTx(m.pending.Add(1))
 	defer func() {
 		m.metr.RecordPendingTx(m.pending.Add(-1))
 	}()
+	var s *inflightSend
+	if candidate.Label != "" {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithCancel(ctx)
+		s = m.trackSend(candidate.Label, cancel)
+		defer m.untrackSend(candidate.Label, s)
+	}
+	if err := m.beforeCraft(ctx, &candidate); err != nil {
+		err = fmt.Errorf("rejected by hook: %w", err)
+		m.afterFailure(ctx, candidate, err)
+		return nil, err
+	}
+	if len(candidate.Labels) > 0 {
+		ctx = context.WithValue(ctx, loggerKey{}, m.l.New(labelsCtx(candidate.Labels)...))
+	}
 	receipt, err := m.send(ctx, candidate)
 	if err != nil {
 		m.resetNonce()
+		if s != nil && s.aborted.Load() {
+			err = fmt.Errorf("%w: %v", ErrAborted, err)
+		}
+		m.afterFailure(ctx, candidate, err)
+		return nil, err
+	}
+	return receipt, nil
+}
+
+type loggerKey struct{}
+
+// logger returns the logger of the send that ctx belongs to, which carries the
+// labels of its candidate.
+func (m *SimpleTxManager) logger(ctx context.Context) log.Logger {
+	if l, ok := ctx.Value(loggerKey{}).(log.Logger); ok {
+		return l
+	}
+	return m.l
+}
+
+// labelsCtx returns the labels as log context, sorted by key.
+func labelsCtx(labels map[string]string) []interface{} {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	ctx := make([]interface{}, 0, 2*len(keys))
+	for _, k := range keys {
+		ctx = append(ctx, k, labels[k])
+	}
+	return ctx
+}
+
+// beginSend registers a send, unless the transaction manager is closed. The
+// returned context is canceled if Close gives up waiting for the send, and done
+// must be called when the send finished.
+func (m *SimpleTxManager) beginSend(ctx context.Context) (context.Context, func(), error) {
+	m.inflightLock.Lock()
+	defer m.inflightLock.Unlock()
+	if m.closed {
+		return nil, nil, ErrClosed
+	}
+	if m.closing == nil {
+		m.closing = make(chan struct{})
+	}
+	m.sends.Add(1)
+	ctx, cancel := context.WithCancel(ctx)
+	go func(closing chan struct{}) {
+		select {
+		case <-closing:
+			cancel()
+		case <-ctx.Done():
+		}
+	}(m.closing)
+	return ctx, func() {
+		cancel()
+		m.sends.Done()
+	}, nil
+}
+
+// Close stops accepting new sends and waits for the in-flight ones, until ctx
+// is done. Sends canceled by Close keep their transactions in the journal, if
+// enabled, so that they are accounted for after a restart.
+func (m *SimpleTxManager) Close(ctx context.Context) error {
+	m.inflightLock.Lock()
+	if m.closed {
+		m.inflightLock.Unlock()
+		return nil
+	}
+	m.closed = true
+	if m.closing == nil {
+		m.closing = make(chan struct{})
+	}
+	closing := m.closing
+	m.inflightLock.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		m.sends.Wait()
+		close(done)
+	}()
+	var err error
+	select {
+	case <-done:
+	case <-ctx.Done():
+		m.l.Warn("Canceling in-flight sends on close", "pending", m.pending.Load())
+		close(closing)
+		<-done
+		err = ctx.Err()
+	}
+	if c, ok := m.backend.(interface{ Close() }); ok {
+		c.Close()
+	}
+	if m.journal != nil {
+		if jerr := m.journal.Close(); jerr != nil {
+			m.l.Warn("Failed to close journal", "err", jerr)
+		}
+	}
+	return err
+}
+
+// Abort cancels all in-flight sends of candidates with the given label.
+func (m *SimpleTxManager) Abort(label string) int {
+	m.inflightLock.Lock()
+	defer m.inflightLock.Unlock()
+	sends := m.inflight[label]
+	for s := range sends {
+		s.aborted.Store(true)
+		s.cancel()
+	}
+	if len(sends) > 0 {
+		m.l.Info("Aborted transaction sends", "label", label, "count", len(sends))
+	}
+	return len(sends)
+}
+
+func (m *SimpleTxManager) trackSend(label string, cancel context.CancelFunc) *inflightSend {
+	m.inflightLock.Lock()
+	defer m.inflightLock.Unlock()
+	if m.inflight == nil {
+		m.inflight = make(map[string]map[*inflightSend]struct{})
+	}
+	if m.inflight[label] == nil {
+		m.inflight[label] = make(map[*inflightSend]struct{})
+	}
+	s := &inflightSend{cancel: cancel}
+	m.inflight[label][s] = struct{}{}
+	return s
+}
+
+func (m *SimpleTxManager) untrackSend(label string, s *inflightSend) {
+	m.inflightLock.Lock()
+	defer m.inflightLock.Unlock()
+	s.cancel()
+	delete(m.inflight[label], s)
+	if len(m.inflight[label]) == 0 {
+		delete(m.inflight, label)
 	}
-	return receipt, err
 }
 
 // send performs the actual transaction creation and sending.
@@ -151,11 +453,227 @@ func (m *SimpleTxManager) send(ctx context.Context, candidate TxCandidate) (*typ
 		ctx, cancel = context.WithTimeout(ctx, m.cfg.TxSendTimeout)
 		defer cancel()
 	}
-	tx, err := m.craftTx(ctx, candidate)
+	if m.breaker != nil {
+		if err := m.breaker.wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+	var tx *types.Transaction
+	if m.journal != nil {
+		var receipt *types.Receipt
+		var err error
+		if tx, receipt, err = m.resume(ctx, candidate); err != nil || receipt != nil {
+			return receipt, err
+		}
+	}
+	if err := m.checkPoolBacklog(ctx); err != nil {
+		return nil, err
+	}
+	if err := m.checkIncludeBy(ctx, candidate.IncludeBy); err != nil {
+		return nil, err
+	}
+	if tx == nil {
+		var err error
+		if tx, err = m.craftTx(ctx, candidate); err != nil {
+			return nil, fmt.Errorf("failed to create the tx: %w", err)
+		}
+		if err := m.checkMaxTxFee(tx); err != nil {
+			return nil, err
+		}
+		if err := m.checkBalance(ctx, tx); err != nil {
+			return nil, err
+		}
+	}
+	receipt, err := m.sendTx(ctx, tx, candidate.IncludeBy)
+	// Keep the journal entry if the send was interrupted, e.g. by a shutdown or
+	// an abort, as its transaction may still be in the mempool. The next
+	// transaction with its nonce is priced to replace it.
+	if m.journal != nil && (err == nil || ctx.Err() == nil) {
+		m.unjournal(tx.Nonce())
+	}
+	return receipt, err
+}
+
+// resume looks up a journaled transaction with the data of the candidate, e.g.
+// of a send interrupted by a restart. If one of the transactions with its nonce
+// was included, it waits for its confirmation and returns the receipt.
+// Otherwise, if its nonce is still the next to use, that transaction is
+// returned to resume sending it. If neither is the case, the journal entry is
+// stale and dropped.
+func (m *SimpleTxManager) resume(ctx context.Context, candidate TxCandidate) (*types.Transaction, *types.Receipt, error) {
+	tx, hashes, ok := m.journal.Find(candidate.To, candidate.TxData)
+	if !ok {
+		return nil, nil, nil
+	}
+	for _, hash := range hashes {
+		cCtx, cancel := context.WithTimeout(ctx, m.cfg.NetworkTimeout)
+		receipt, err := m.backend.TransactionReceipt(cCtx, hash)
+		cancel()
+		if err != nil || receipt == nil {
+			continue
+		}
+		m.logger(ctx).Info("Resuming confirmation of journaled transaction", "hash", hash)
+		sendState := NewSendState(m.cfg.SafeAbortNonceTooLowCount, m.cfg.TxNotInMempoolTimeout)
+		if receipt, err = m.waitMinedHash(ctx, hash, sendState); err != nil {
+			return nil, nil, err
+		}
+		m.unjournal(tx.Nonce())
+		m.metr.TxConfirmed(receipt)
+		m.afterConfirm(ctx, receipt)
+		return nil, receipt, nil
+	}
+	ok, err := m.reserveNonce(ctx, tx.Nonce())
 	if err != nil {
-		return nil, fmt.Errorf("failed to create the tx: %w", err)
+		return nil, nil, err
+	} else if !ok {
+		m.logger(ctx).Info("Dropping stale journaled transaction", "hash", tx.Hash(), "nonce", tx.Nonce())
+		m.unjournal(tx.Nonce())
+		return nil, nil, nil
+	}
+	m.logger(ctx).Info("Resuming journaled transaction", "hash", tx.Hash(), "nonce", tx.Nonce())
+	return tx, nil, nil
+}
+
+// journalTx records a published transaction in the journal, if enabled.
+func (m *SimpleTxManager) journalTx(tx *types.Transaction) {
+	if m.journal == nil {
+		return
+	}
+	if err := m.journal.Add(tx); err != nil {
+		m.l.Warn("Failed to journal transaction", "hash", tx.Hash(), "err", err)
 	}
-	return m.sendTx(ctx, tx)
+}
+
+func (m *SimpleTxManager) unjournal(nonce uint64) {
+	if err := m.journal.Remove(nonce); err != nil {
+		m.l.Warn("Failed to remove transaction from journal", "nonce", nonce, "err", err)
+	}
+}
+
+// pruneJournal drops the journaled transactions with nonces below the latest
+// nonce of the sender, which can't be included anymore.
+func (m *SimpleTxManager) pruneJournal(latest uint64) {
+	if m.journal == nil {
+		return
+	}
+	if err := m.journal.Prune(latest); err != nil {
+		m.l.Warn("Failed to prune journal", "nonce", latest, "err", err)
+	}
+}
+
+// priceReplacement raises the fees of tx, if needed, so that it replaces the
+// journaled transaction with the same nonce. That transaction may still be in
+// the mempool, e.g. if it was published before a restart with other data.
+func (m *SimpleTxManager) priceReplacement(ctx context.Context, tx *types.DynamicFeeTx) {
+	if m.journal == nil {
+		return
+	}
+	prev, _, ok := m.journal.Get(tx.Nonce)
+	if !ok {
+		return
+	}
+	if tip := calcThresholdValue(prev.GasTipCap()); tx.GasTipCap.Cmp(tip) < 0 {
+		tx.GasTipCap = tip
+	}
+	if feeCap := calcThresholdValue(prev.GasFeeCap()); tx.GasFeeCap.Cmp(feeCap) < 0 {
+		tx.GasFeeCap = feeCap
+	}
+	m.logger(ctx).Info("Replacing journaled transaction", "hash", prev.Hash(), "nonce", tx.Nonce,
+		"gasTipCap", tx.GasTipCap, "gasFeeCap", tx.GasFeeCap)
+}
+
+// checkBalance ensures that the sender can pay for the maximum cost of tx, so
+// that a send that cannot succeed fails right away instead of being resubmitted
+// until it times out.
+func (m *SimpleTxManager) checkBalance(ctx context.Context, tx *types.Transaction) error {
+	ctx, cancel := context.WithTimeout(ctx, m.cfg.NetworkTimeout)
+	defer cancel()
+	balance, err := m.backend.BalanceAt(ctx, m.cfg.From, nil)
+	if err != nil {
+		m.observeRPCError(err)
+		return fmt.Errorf("failed to get balance: %w", err)
+	}
+	if cost := tx.Cost(); balance.Cmp(cost) < 0 {
+		m.logger(ctx).Error("Sender cannot pay for transaction", "balance", balance, "cost", cost)
+		return fmt.Errorf("%w: balance %v, cost %v", ErrInsufficientFunds, balance, cost)
+	}
+	return nil
+}
+
+// checkIncludeBy returns ErrIncludeByPassed if L1 is at or past the block that a
+// tx must be included by, so that it can't be included in time anymore.
+func (m *SimpleTxManager) checkIncludeBy(ctx context.Context, includeBy uint64) error {
+	if includeBy == 0 {
+		return nil
+	}
+	cCtx, cancel := context.WithTimeout(ctx, m.cfg.NetworkTimeout)
+	defer cancel()
+	tip, err := m.backend.BlockNumber(cCtx)
+	if err != nil {
+		// checked again on the next resubmission
+		m.logger(ctx).Warn("Unable to fetch block number to check the inclusion deadline", "err", err)
+		return nil
+	}
+	if tip >= includeBy {
+		return fmt.Errorf("%w: L1 is at block %d, include by %d", ErrIncludeByPassed, tip, includeBy)
+	}
+	return nil
+}
+
+// checkMaxTxFee ensures that the maximum fee of tx, at its fee cap, doesn't
+// exceed the configured maximum.
+func (m *SimpleTxManager) checkMaxTxFee(tx *types.Transaction) error {
+	if m.cfg.MaxTxFee == nil {
+		return nil
+	}
+	fee := new(big.Int).Mul(tx.GasFeeCap(), new(big.Int).SetUint64(tx.Gas()))
+	if fee.Cmp(m.cfg.MaxTxFee) > 0 {
+		m.metr.MaxTxFeeExceeded()
+		return fmt.Errorf("%w: fee %v wei, max %v wei", ErrMaxTxFee, fee, m.cfg.MaxTxFee)
+	}
+	return nil
+}
+
+// checkPoolBacklog compares the pending and latest nonce of the sender to find the
+// number of its transactions in the mempool. Transactions of concurrent sends of this
+// transaction manager are expected there, as well as the journaled ones, which may be
+// from before a restart. Any others indicate a backlog, for example from a second
+// instance using the same account.
+func (m *SimpleTxManager) checkPoolBacklog(ctx context.Context) error {
+	if m.cfg.MaxPoolBacklog == 0 {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, m.cfg.NetworkTimeout)
+	defer cancel()
+	latest, err := m.backend.NonceAt(ctx, m.cfg.From, nil)
+	if err != nil {
+		m.observeRPCError(err)
+		return fmt.Errorf("failed to get nonce: %w", err)
+	}
+	pending, err := m.backend.PendingNonceAt(ctx, m.cfg.From)
+	if err != nil {
+		m.observeRPCError(err)
+		return fmt.Errorf("failed to get pending nonce: %w", err)
+	}
+	if pending <= latest {
+		return nil
+	}
+	// m.pending includes the current send, which is not in the mempool yet
+	own := uint64(0)
+	if p := m.pending.Load(); p > 1 {
+		own = uint64(p - 1)
+	}
+	// the journal includes the published txs of concurrent sends
+	if m.journal != nil {
+		if n := uint64(m.journal.Count(latest, pending)); n > own {
+			own = n
+		}
+	}
+	if backlog := pending - latest; backlog > own && backlog-own > m.cfg.MaxPoolBacklog {
+		m.logger(ctx).Warn("Sender has a mempool backlog, refusing to send", "pool", backlog, "own", own, "max", m.cfg.MaxPoolBacklog)
+		return fmt.Errorf("%w: %d pending, %d sent by this instance", ErrPoolBacklog, backlog, own)
+	}
+	return nil
 }
 
 // craftTx creates the signed transaction
@@ -164,7 +682,7 @@ func (m *SimpleTxManager) send(ctx context.Context, candidate TxCandidate) (*typ
 // NOTE: If the [TxCandidate.GasLimit] is non-zero, it will be used as the transaction's gas.
 // NOTE: Otherwise, the [SimpleTxManager] will query the specified backend for an estimate.
 func (m *SimpleTxManager) craftTx(ctx context.Context, candidate TxCandidate) (*types.Transaction, error) {
-	gasTipCap, basefee, err := m.suggestGasPriceCaps(ctx)
+	gasTipCap, basefee, err := m.cachedGasPriceCaps(ctx)
 	if err != nil {
 		m.metr.RPCError()
 		return nil, fmt.Errorf("failed to get gas price info: %w", err)
@@ -184,8 +702,9 @@ func (m *SimpleTxManager) craftTx(ctx context.Context, candidate TxCandidate) (*
 		GasFeeCap: gasFeeCap,
 		Data:      candidate.TxData,
 	}
+	m.priceReplacement(ctx, rawTx)
 
-	m.l.Info("creating tx", "to", rawTx.To, "from", m.cfg.From)
+	m.logger(ctx).Info("creating tx", "to", rawTx.To, "from", m.cfg.From)
 
 	// If the gas limit is set, we can use that as the gas
 	if candidate.GasLimit != 0 {
@@ -195,8 +714,8 @@ func (m *SimpleTxManager) craftTx(ctx context.Context, candidate TxCandidate) (*
 		gas, err := m.backend.EstimateGas(ctx, ethereum.CallMsg{
 			From:      m.cfg.From,
 			To:        candidate.To,
-			GasFeeCap: gasFeeCap,
-			GasTipCap: gasTipCap,
+			GasFeeCap: rawTx.GasFeeCap,
+			GasTipCap: rawTx.GasTipCap,
 			Data:      rawTx.Data,
 		})
 		if err != nil {
@@ -205,9 +724,54 @@ func (m *SimpleTxManager) craftTx(ctx context.Context, candidate TxCandidate) (*
 		rawTx.Gas = gas
 	}
 
+	if m.cfg.SimulateTxs {
+		if err := m.simulate(ctx, rawTx); err != nil {
+			return nil, err
+		}
+	}
+
+	unsignedTx := types.NewTx(rawTx)
+	if err := m.beforeSign(ctx, unsignedTx); err != nil {
+		return nil, fmt.Errorf("rejected by hook: %w", err)
+	}
+
 	ctx, cancel := context.WithTimeout(ctx, m.cfg.NetworkTimeout)
 	defer cancel()
-	return m.cfg.Signer(ctx, m.cfg.From, types.NewTx(rawTx))
+	return m.cfg.Signer(ctx, m.cfg.From, unsignedTx)
+}
+
+// simulate executes the transaction with eth_call, so that one that would revert
+// is never signed and published. The revert reason is decoded, if available.
+func (m *SimpleTxManager) simulate(ctx context.Context, tx *types.DynamicFeeTx) error {
+	ctx, cancel := context.WithTimeout(ctx, m.cfg.NetworkTimeout)
+	defer cancel()
+	_, err := m.backend.CallContract(ctx, ethereum.CallMsg{
+		From:      m.cfg.From,
+		To:        tx.To,
+		Gas:       tx.Gas,
+		GasFeeCap: tx.GasFeeCap,
+		GasTipCap: tx.GasTipCap,
+		Value:     tx.Value,
+		Data:      tx.Data,
+	}, nil)
+	if err == nil {
+		return nil
+	}
+	// JSON-RPC errors are responses of the node, any others are transport failures
+	var rpcErr rpc.Error
+	if !errors.As(err, &rpcErr) {
+		m.observeRPCError(err)
+		return fmt.Errorf("failed to simulate tx: %w", err)
+	}
+	var dataErr rpc.DataError
+	if errors.As(err, &dataErr) {
+		if data, ok := dataErr.ErrorData().(string); ok {
+			if reason, uerr := abi.UnpackRevert(common.FromHex(data)); uerr == nil {
+				return fmt.Errorf("%w: %v: %s", ErrSimulationFailed, err, reason)
+			}
+		}
+	}
+	return fmt.Errorf("%w: %v", ErrSimulationFailed, err)
 }
 
 // nextNonce returns a nonce to use for the next transaction. It uses
@@ -224,9 +788,10 @@ func (m *SimpleTxManager) nextNonce(ctx context.Context) (uint64, error) {
 		defer cancel()
 		nonce, err := m.backend.NonceAt(childCtx, m.cfg.From, nil)
 		if err != nil {
-			m.metr.RPCError()
+			m.observeRPCError(err)
 			return 0, fmt.Errorf("failed to get nonce: %w", err)
 		}
+		m.pruneJournal(nonce)
 		m.nonce = &nonce
 	} else {
 		*m.nonce++
@@ -236,6 +801,34 @@ func (m *SimpleTxManager) nextNonce(ctx context.Context) (uint64, error) {
 	return *m.nonce, nil
 }
 
+// reserveNonce uses nonce for a resumed transaction, if it is the nonce that
+// nextNonce would return.
+func (m *SimpleTxManager) reserveNonce(ctx context.Context, nonce uint64) (bool, error) {
+	m.nonceLock.Lock()
+	defer m.nonceLock.Unlock()
+
+	var next uint64
+	if m.nonce == nil {
+		childCtx, cancel := context.WithTimeout(ctx, m.cfg.NetworkTimeout)
+		defer cancel()
+		latest, err := m.backend.NonceAt(childCtx, m.cfg.From, nil)
+		if err != nil {
+			m.observeRPCError(err)
+			return false, fmt.Errorf("failed to get nonce: %w", err)
+		}
+		m.pruneJournal(latest)
+		next = latest
+	} else {
+		next = *m.nonce + 1
+	}
+	if next != nonce {
+		return false, nil
+	}
+	m.nonce = &nonce
+	m.metr.RecordNonce(nonce)
+	return true, nil
+}
+
 // resetNonce resets the internal nonce tracking. This is called if any pending send
 // returns an error.
 func (m *SimpleTxManager) resetNonce() {
@@ -246,51 +839,96 @@ func (m *SimpleTxManager) resetNonce() {
 
 // send submits the same transaction several times with increasing gas prices as necessary.
 // It waits for the transaction to be confirmed on chain.
-func (m *SimpleTxManager) sendTx(ctx context.Context, tx *types.Transaction) (*types.Receipt, error) {
+func (m *SimpleTxManager) sendTx(ctx context.Context, tx *types.Transaction, includeBy uint64) (*types.Receipt, error) {
 	var wg sync.WaitGroup
 	defer wg.Wait()
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
 	sendState := NewSendState(m.cfg.SafeAbortNonceTooLowCount, m.cfg.TxNotInMempoolTimeout)
+	m.metr.RecordTxStateTransition(m.name, "", TxStateCrafted.String(), false)
+	sendState.onTransition = func(from, to TxState) {
+		m.metr.RecordTxStateTransition(m.name, from.String(), to.String(), to.Final())
+	}
+	// sends that end for any other reason were aborted by the caller or a hook
+	defer sendState.Finish(TxStateAborted)
 	receiptChan := make(chan *types.Receipt, 1)
 	sendTxAsync := func(tx *types.Transaction) {
 		defer wg.Done()
 		m.publishAndWaitForTx(ctx, tx, sendState, receiptChan)
 	}
 
+	if err := m.beforePublish(ctx, tx); err != nil {
+		return nil, fmt.Errorf("rejected by hook: %w", err)
+	}
+	m.journalTx(tx)
+	progress := m.trackProgress(tx, sendState)
+	defer m.untrackProgress(progress)
+
 	// Immediately publish a transaction before starting the resumbission loop
 	wg.Add(1)
 	go sendTxAsync(tx)
 
-	ticker := time.NewTicker(m.cfg.ResubmissionTimeout)
+	attempt := 0
+	timeout := m.resubmissionTimeout(attempt)
+	ticker := time.NewTicker(timeout)
 	defer ticker.Stop()
+	progress.scheduled(time.Now().Add(timeout))
 
 	bumpCounter := 0
 	for {
 		select {
 		case <-ticker.C:
+			attempt++
+			timeout = m.resubmissionTimeout(attempt)
+			ticker.Reset(timeout)
+			progress.scheduled(time.Now().Add(timeout))
+			m.recordSendState()
 			// Don't resubmit a transaction if it has been mined, but we are waiting for the conf depth.
 			if sendState.IsWaitingForConfirmation() {
 				continue
 			}
 			// If we see lots of unrecoverable errors (and no pending transactions) abort sending the transaction.
-			if sendState.ShouldAbortImmediately() {
-				m.l.Warn("Aborting transaction submission")
-				return nil, errors.New("aborted transaction sending")
+			if err := sendState.AbortReason(); err != nil {
+				m.logger(ctx).Warn("Aborting transaction submission", "err", err)
+				sendState.Finish(TxStateFailed)
+				return nil, fmt.Errorf("aborted transaction sending: %w", err)
+			}
+			if err := m.checkIncludeBy(ctx, includeBy); err != nil {
+				m.logger(ctx).Warn("Aborting transaction submission", "err", err)
+				sendState.Finish(TxStateExpired)
+				return nil, fmt.Errorf("aborted transaction sending: %w", err)
 			}
 			// Increase the gas price & submit the new transaction
-			tx = m.increaseGasPrice(ctx, tx)
+			if bumpedTx := m.increaseGasPrice(ctx, tx); bumpedTx != tx {
+				if err := m.checkMaxTxFee(bumpedTx); err != nil {
+					m.logger(ctx).Warn("Aborting transaction submission, fee bump exceeds the max fee", "err", err)
+					sendState.Finish(TxStateFailed)
+					return nil, fmt.Errorf("aborted transaction sending: %w", err)
+				}
+				if err := m.beforePublish(ctx, bumpedTx); err != nil {
+					m.logger(ctx).Warn("Fee bump rejected by hook, resubmitting previous transaction", "err", err)
+				} else {
+					tx = bumpedTx
+					m.journalTx(tx)
+					progress.published(tx)
+				}
+			}
 			wg.Add(1)
 			bumpCounter += 1
 			go sendTxAsync(tx)
 
 		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				sendState.Finish(TxStateExpired)
+			}
 			return nil, ctx.Err()
 
 		case receipt := <-receiptChan:
+			sendState.Finish(TxStateConfirmed)
 			m.metr.RecordGasBumpCount(bumpCounter)
 			m.metr.TxConfirmed(receipt)
+			m.afterConfirm(ctx, receipt)
 			return receipt, nil
 		}
 	}
@@ -300,7 +938,7 @@ func (m *SimpleTxManager) sendTx(ctx context.Context, tx *types.Transaction) (*t
 // It should be called in a new go-routine. It will send the receipt to receiptChan in a non-blocking way if a receipt is found
 // for the transaction.
 func (m *SimpleTxManager) publishAndWaitForTx(ctx context.Context, tx *types.Transaction, sendState *SendState, receiptChan chan *types.Receipt) {
-	log := m.l.New("hash", tx.Hash(), "nonce", tx.Nonce(), "gasTipCap", tx.GasTipCap(), "gasFeeCap", tx.GasFeeCap())
+	log := m.logger(ctx).New("hash", tx.Hash(), "nonce", tx.Nonce(), "gasTipCap", tx.GasTipCap(), "gasFeeCap", tx.GasFeeCap())
 	log.Info("publishing transaction")
 
 	cCtx, cancel := context.WithTimeout(ctx, m.cfg.NetworkTimeout)
@@ -328,8 +966,14 @@ func (m *SimpleTxManager) publishAndWaitForTx(ctx context.Context, tx *types.Tra
 		case errStringMatch(err, txpool.ErrUnderpriced):
 			log.Warn("transaction is underpriced", "err", err)
 			m.metr.TxPublished("tx_underpriced")
+		case errStringMatch(err, core.ErrInsufficientFunds):
+			log.Error("insufficient funds", "err", err)
+			m.metr.TxPublished("insufficient_funds")
+		case isFatalSendError(err):
+			log.Error("transaction rejected", "err", err)
+			m.metr.TxPublished("rejected")
 		default:
-			m.metr.RPCError()
+			m.observeRPCError(err)
 			log.Error("unable to publish transaction", "err", err)
 			m.metr.TxPublished("unknown_error")
 		}
@@ -353,7 +997,11 @@ func (m *SimpleTxManager) publishAndWaitForTx(ctx context.Context, tx *types.Tra
 
 // waitMined waits for the transaction to be mined or for the context to be cancelled.
 func (m *SimpleTxManager) waitMined(ctx context.Context, tx *types.Transaction, sendState *SendState) (*types.Receipt, error) {
-	txHash := tx.Hash()
+	return m.waitMinedHash(ctx, tx.Hash(), sendState)
+}
+
+// waitMinedHash is like waitMined, for the transaction with the given hash.
+func (m *SimpleTxManager) waitMinedHash(ctx context.Context, txHash common.Hash, sendState *SendState) (*types.Receipt, error) {
 	queryTicker := time.NewTicker(m.cfg.ReceiptQueryInterval)
 	defer queryTicker.Stop()
 	for {
@@ -375,15 +1023,15 @@ func (m *SimpleTxManager) queryReceipt(ctx context.Context, txHash common.Hash,
 	receipt, err := m.backend.TransactionReceipt(ctx, txHash)
 	if errors.Is(err, ethereum.NotFound) {
 		sendState.TxNotMined(txHash)
-		m.l.Trace("Transaction not yet mined", "hash", txHash)
+		m.logger(ctx).Trace("Transaction not yet mined", "hash", txHash)
 		return nil
 	} else if err != nil {
-		m.metr.RPCError()
-		m.l.Info("Receipt retrieval failed", "hash", txHash, "err", err)
+		m.observeRPCError(err)
+		m.logger(ctx).Info("Receipt retrieval failed", "hash", txHash, "err", err)
 		return nil
 	} else if receipt == nil {
 		m.metr.RPCError()
-		m.l.Warn("Receipt and error are both nil", "hash", txHash)
+		m.logger(ctx).Warn("Receipt and error are both nil", "hash", txHash)
 		return nil
 	}
 
@@ -393,11 +1041,14 @@ func (m *SimpleTxManager) queryReceipt(ctx context.Context, txHash common.Hash,
 	txHeight := receipt.BlockNumber.Uint64()
 	tipHeight, err := m.backend.BlockNumber(ctx)
 	if err != nil {
-		m.l.Error("Unable to fetch block number", "err", err)
+		m.logger(ctx).Error("Unable to fetch block number", "err", err)
 		return nil
 	}
+	if m.feeCache != nil {
+		m.feeCache.ObserveHead(tipHeight)
+	}
 
-	m.l.Debug("Transaction mined, checking confirmations", "hash", txHash, "txHeight", txHeight,
+	m.logger(ctx).Debug("Transaction mined, checking confirmations", "hash", txHash, "txHeight", txHeight,
 		"tipHeight", tipHeight, "numConfirmations", m.cfg.NumConfirmations)
 
 	// The transaction is considered confirmed when
@@ -408,19 +1059,19 @@ func (m *SimpleTxManager) queryReceipt(ctx context.Context, txHash common.Hash,
 	// tipHeight. The equation is rewritten in this form to avoid
 	// underflows.
 	if txHeight+m.cfg.NumConfirmations <= tipHeight+1 {
-		m.l.Info("Transaction confirmed", "hash", txHash)
+		m.logger(ctx).Info("Transaction confirmed", "hash", txHash)
 		return receipt
 	}
 
 	// Safe to subtract since we know the LHS above is greater.
 	confsRemaining := (txHeight + m.cfg.NumConfirmations) - (tipHeight + 1)
-	m.l.Debug("Transaction not yet confirmed", "hash", txHash, "confsRemaining", confsRemaining)
+	m.logger(ctx).Debug("Transaction not yet confirmed", "hash", txHash, "confsRemaining", confsRemaining)
 	return nil
 }
 
 // increaseGasPrice takes the previous transaction & potentially clones then signs it with a higher tip.
 // If the tip + basefee suggested by the network are not greater than the previous values, the same transaction
-// will be returned. If they are greater, this function will ensure that they are at least greater by 15% than
+// will be returned without re-signing it, so that resubmissions keep the same hash. If they are greater, this function will ensure that they are at least greater by 15% than
 // the previous transaction's value to ensure that the price bump is large enough.
 //
 // We do not re-estimate the amount of gas used because for some stateful transactions (like output proposals) the
@@ -430,10 +1081,10 @@ func (m *SimpleTxManager) queryReceipt(ctx context.Context, txHash common.Hash,
 func (m *SimpleTxManager) increaseGasPrice(ctx context.Context, tx *types.Transaction) *types.Transaction {
 	tip, basefee, err := m.suggestGasPriceCaps(ctx)
 	if err != nil {
-		m.l.Warn("failed to get suggested gas tip and basefee", "err", err)
+		m.logger(ctx).Warn("failed to get suggested gas tip and basefee", "err", err)
 		return tx
 	}
-	gasTipCap, gasFeeCap := updateFees(tx.GasTipCap(), tx.GasFeeCap(), tip, basefee, m.l)
+	gasTipCap, gasFeeCap := updateFees(tx.GasTipCap(), tx.GasFeeCap(), tip, basefee, m.logger(ctx))
 
 	if tx.GasTipCapIntCmp(gasTipCap) == 0 && tx.GasFeeCapIntCmp(gasFeeCap) == 0 {
 		return tx
@@ -450,11 +1101,16 @@ func (m *SimpleTxManager) increaseGasPrice(ctx context.Context, tx *types.Transa
 		Data:       tx.Data(),
 		AccessList: tx.AccessList(),
 	}
+	unsignedTx := types.NewTx(rawTx)
+	if err := m.beforeSign(ctx, unsignedTx); err != nil {
+		m.logger(ctx).Warn("Fee bump rejected by hook", "err", err)
+		return tx
+	}
 	ctx, cancel := context.WithTimeout(ctx, m.cfg.NetworkTimeout)
 	defer cancel()
-	newTx, err := m.cfg.Signer(ctx, m.cfg.From, types.NewTx(rawTx))
+	newTx, err := m.cfg.Signer(ctx, m.cfg.From, unsignedTx)
 	if err != nil {
-		m.l.Warn("failed to sign new transaction", "err", err)
+		m.logger(ctx).Warn("failed to sign new transaction", "err", err)
 		return tx
 	}
 	return newTx
@@ -466,7 +1122,7 @@ func (m *SimpleTxManager) suggestGasPriceCaps(ctx context.Context) (*big.Int, *b
 	defer cancel()
 	tip, err := m.backend.SuggestGasTipCap(cCtx)
 	if err != nil {
-		m.metr.RPCError()
+		m.observeRPCError(err)
 		return nil, nil, fmt.Errorf("failed to fetch the suggested gas tip cap: %w", err)
 	} else if tip == nil {
 		return nil, nil, errors.New("the suggested tip was nil")
@@ -475,14 +1131,33 @@ func (m *SimpleTxManager) suggestGasPriceCaps(ctx context.Context) (*big.Int, *b
 	defer cancel()
 	head, err := m.backend.HeaderByNumber(cCtx, nil)
 	if err != nil {
-		m.metr.RPCError()
+		m.observeRPCError(err)
 		return nil, nil, fmt.Errorf("failed to fetch the suggested basefee: %w", err)
 	} else if head.BaseFee == nil {
 		return nil, nil, errors.New("txmgr does not support pre-london blocks that do not have a basefee")
 	}
+	if m.feeCache != nil {
+		var number uint64
+		if head.Number != nil {
+			number = head.Number.Uint64()
+		}
+		m.feeCache.Set(tip, head.BaseFee, number)
+	}
 	return tip, head.BaseFee, nil
 }
 
+// cachedGasPriceCaps is like suggestGasPriceCaps, but reuses recently suggested
+// values if the fee cache is enabled. It is only used to craft new transactions,
+// fee bumps always use fresh values.
+func (m *SimpleTxManager) cachedGasPriceCaps(ctx context.Context) (*big.Int, *big.Int, error) {
+	if m.feeCache != nil {
+		if tip, basefee, ok := m.feeCache.Get(); ok {
+			return tip, basefee, nil
+		}
+	}
+	return m.suggestGasPriceCaps(ctx)
+}
+
 // calcThresholdValue returns x * priceBumpPercent / 100
 func calcThresholdValue(x *big.Int) *big.Int {
 	threshold := new(big.Int).Mul(priceBumpPercent, x)
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"path/filepath"
 	"sync"
 	"testing"
 	"time"
@@ -17,6 +18,7 @@ import (
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/txpool"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
 )
@@ -153,6 +155,11 @@ type mockBackend struct {
 	// blockHeight tracks the current height of the chain.
 	blockHeight uint64
 
+	// balance of the sender, unlimited if nil.
+	balance *big.Int
+	// callErr is returned by eth_calls.
+	callErr error
+
 	// minedTxs maps the hash of a mined transaction to its details.
 	minedTxs map[common.Hash]minedTxInfo
 }
@@ -204,6 +211,10 @@ func (b *mockBackend) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (ui
 	return b.g.basefee().Uint64(), nil
 }
 
+func (b *mockBackend) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	return nil, b.callErr
+}
+
 func (b *mockBackend) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
 	tip, _ := b.g.sample()
 	return tip, nil
@@ -224,6 +235,15 @@ func (b *mockBackend) PendingNonceAt(ctx context.Context, account common.Address
 	return 0, nil
 }
 
+func (b *mockBackend) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if b.balance == nil {
+		return new(big.Int).Lsh(big.NewInt(1), 128), nil
+	}
+	return b.balance, nil
+}
+
 func (*mockBackend) ChainID(ctx context.Context) (*big.Int, error) {
 	return big.NewInt(1), nil
 }
@@ -277,7 +297,7 @@ func TestTxMgrConfirmAtMinGasPrice(t *testing.T) {
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
-	receipt, err := h.mgr.sendTx(ctx, tx)
+	receipt, err := h.mgr.sendTx(ctx, tx, 0)
 	require.Nil(t, err)
 	require.NotNil(t, receipt)
 	require.Equal(t, gasPricer.expGasFeeCap().Uint64(), receipt.GasUsed)
@@ -305,9 +325,262 @@ func TestTxMgrNeverConfirmCancel(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	receipt, err := h.mgr.sendTx(ctx, tx)
+	receipt, err := h.mgr.sendTx(ctx, tx, 0)
+	require.Equal(t, err, context.DeadlineExceeded)
+	require.Nil(t, receipt)
+}
+
+// TestTxMgrResubmitsIdenticalTx asserts that, as long as no fee bump is
+// required, resubmissions publish the very same signed transaction instead of
+// re-signing it, so that the tx hash stays stable across the resubmission loop.
+func TestTxMgrResubmitsIdenticalTx(t *testing.T) {
+	t.Parallel()
+
+	cfg := configWithNumConfs(1)
+	cfg.ResubmissionTimeout = 10 * time.Millisecond
+	var signed int
+	cfg.Signer = func(ctx context.Context, from common.Address, tx *types.Transaction) (*types.Transaction, error) {
+		signed++
+		return tx, nil
+	}
+	h := newTestHarnessWithConfig(t, cfg)
+
+	// Fees well above what the gas pricer suggests, so no bump is necessary.
+	tx := types.NewTx(&types.DynamicFeeTx{
+		GasTipCap: big.NewInt(1000),
+		GasFeeCap: big.NewInt(10000),
+	})
+	var (
+		mu     sync.Mutex
+		hashes []common.Hash
+	)
+	sendTx := func(ctx context.Context, tx *types.Transaction) error {
+		mu.Lock()
+		defer mu.Unlock()
+		hashes = append(hashes, tx.Hash())
+		return nil
+	}
+	h.backend.setTxSender(sendTx)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	receipt, err := h.mgr.sendTx(ctx, tx, 0)
 	require.Equal(t, err, context.DeadlineExceeded)
 	require.Nil(t, receipt)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Greater(t, len(hashes), 1, "tx should have been resubmitted")
+	for _, hash := range hashes {
+		require.Equal(t, tx.Hash(), hash)
+	}
+	require.Zero(t, signed, "tx should not have been re-signed")
+}
+
+// TestTxMgrInsufficientFunds asserts that a send fails without publishing any
+// tx if the sender cannot pay for it.
+func TestTxMgrInsufficientFunds(t *testing.T) {
+	t.Parallel()
+
+	h := newTestHarness(t)
+	h.backend.balance = big.NewInt(1)
+	var published bool
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		published = true
+		return nil
+	})
+
+	receipt, err := h.mgr.Send(context.Background(), h.createTxCandidate())
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+	require.Nil(t, receipt)
+	require.False(t, published, "tx should not be published")
+}
+
+// TestTxMgrAbortsOnInsufficientFunds asserts that a send is aborted instead of
+// resubmitted if the L1 endpoint reports that the sender cannot pay for it.
+func TestTxMgrAbortsOnInsufficientFunds(t *testing.T) {
+	t.Parallel()
+
+	h := newTestHarness(t)
+
+	gasTipCap, gasFeeCap := h.gasPricer.sample()
+	tx := types.NewTx(&types.DynamicFeeTx{
+		GasTipCap: gasTipCap,
+		GasFeeCap: gasFeeCap,
+	})
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		return core.ErrInsufficientFunds
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	receipt, err := h.mgr.sendTx(ctx, tx, 0)
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+	require.Nil(t, receipt)
+}
+
+// TestTxMgrAbortsOnRejection asserts that a send is aborted instead of
+// resubmitted if the L1 endpoint rejects the tx for good.
+func TestTxMgrAbortsOnRejection(t *testing.T) {
+	t.Parallel()
+
+	h := newTestHarness(t)
+
+	gasTipCap, gasFeeCap := h.gasPricer.sample()
+	tx := types.NewTx(&types.DynamicFeeTx{
+		GasTipCap: gasTipCap,
+		GasFeeCap: gasFeeCap,
+	})
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		return txpool.ErrOversizedData
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	receipt, err := h.mgr.sendTx(ctx, tx, 0)
+	require.ErrorIs(t, err, ErrRejected)
+	require.ErrorContains(t, err, txpool.ErrOversizedData.Error())
+	require.Nil(t, receipt)
+}
+
+// TestTxMgrMaxTxFee asserts that a send is aborted before publishing if the
+// fee of the crafted tx exceeds the max tx fee.
+func TestTxMgrMaxTxFee(t *testing.T) {
+	t.Parallel()
+
+	cfg := configWithNumConfs(1)
+	cfg.MaxTxFee = big.NewInt(1)
+	h := newTestHarnessWithConfig(t, cfg)
+	var published bool
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		published = true
+		return nil
+	})
+
+	receipt, err := h.mgr.Send(context.Background(), h.createTxCandidate())
+	require.ErrorIs(t, err, ErrMaxTxFee)
+	require.Nil(t, receipt)
+	require.False(t, published, "tx should not be published")
+}
+
+// TestTxMgrIncludeByPassed asserts that a send fails without publishing any tx
+// if L1 is already at the block it must be included by.
+func TestTxMgrIncludeByPassed(t *testing.T) {
+	t.Parallel()
+
+	h := newTestHarness(t)
+	h.backend.mine(nil, nil)
+	var published bool
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		published = true
+		return nil
+	})
+
+	candidate := h.createTxCandidate()
+	candidate.IncludeBy = 1
+	receipt, err := h.mgr.Send(context.Background(), candidate)
+	require.ErrorIs(t, err, ErrIncludeByPassed)
+	require.Nil(t, receipt)
+	require.False(t, published, "tx should not be published")
+}
+
+// TestTxMgrAbortsAfterIncludeBy asserts that a send is aborted instead of
+// resubmitted once L1 reaches the block it must be included by.
+func TestTxMgrAbortsAfterIncludeBy(t *testing.T) {
+	t.Parallel()
+
+	h := newTestHarness(t)
+	gasTipCap, gasFeeCap := h.gasPricer.sample()
+	tx := types.NewTx(&types.DynamicFeeTx{
+		GasTipCap: gasTipCap,
+		GasFeeCap: gasFeeCap,
+	})
+	var published int
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		// L1 progresses without including the tx
+		published++
+		h.backend.mine(nil, nil)
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	receipt, err := h.mgr.sendTx(ctx, tx, 2)
+	require.ErrorIs(t, err, ErrIncludeByPassed)
+	require.Nil(t, receipt)
+	require.Equal(t, 2, published)
+}
+
+// TestTxMgrAbortsFeeBumpAboveMaxTxFee asserts that a send is aborted instead of
+// bumping the fee above the max tx fee.
+func TestTxMgrAbortsFeeBumpAboveMaxTxFee(t *testing.T) {
+	t.Parallel()
+
+	h := newTestHarness(t)
+	gasTipCap, gasFeeCap := h.gasPricer.sample()
+	tx := types.NewTx(&types.DynamicFeeTx{
+		GasTipCap: gasTipCap,
+		GasFeeCap: gasFeeCap,
+		Gas:       1337,
+	})
+	h.mgr.cfg.MaxTxFee = new(big.Int).Mul(gasFeeCap, big.NewInt(1337))
+	var published int
+	// never mine any transaction
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		published++
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	receipt, err := h.mgr.sendTx(ctx, tx, 0)
+	require.ErrorIs(t, err, ErrMaxTxFee)
+	require.Nil(t, receipt)
+	require.Equal(t, 1, published, "only the original tx should be published")
+}
+
+// revertError is a JSON-RPC error with revert data, like geth returns for eth_call.
+type revertError struct {
+	data string
+}
+
+func (revertError) Error() string            { return "execution reverted" }
+func (revertError) ErrorCode() int           { return 3 }
+func (e revertError) ErrorData() interface{} { return e.data }
+
+// TestTxMgrSimulatesTx asserts that a tx that reverts when simulated is not
+// published, and that the revert reason is decoded.
+func TestTxMgrSimulatesTx(t *testing.T) {
+	t.Parallel()
+
+	cfg := configWithNumConfs(1)
+	cfg.SimulateTxs = true
+	h := newTestHarnessWithConfig(t, cfg)
+	// Error("nope")
+	h.backend.callErr = revertError{data: "0x08c379a0" +
+		"0000000000000000000000000000000000000000000000000000000000000020" +
+		"0000000000000000000000000000000000000000000000000000000000000004" +
+		"6e6f706500000000000000000000000000000000000000000000000000000000"}
+	var published bool
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		published = true
+		return nil
+	})
+
+	receipt, err := h.mgr.Send(context.Background(), h.createTxCandidate())
+	require.ErrorIs(t, err, ErrSimulationFailed)
+	require.ErrorContains(t, err, "execution reverted: nope")
+	require.Nil(t, receipt)
+	require.False(t, published, "tx should not be published")
+
+	h.backend.callErr = errors.New("connection refused")
+	_, err = h.mgr.Send(context.Background(), h.createTxCandidate())
+	require.NotErrorIs(t, err, ErrSimulationFailed, "transport errors are not simulation failures")
+	require.False(t, published, "tx should not be published")
 }
 
 // TestTxMgrConfirmsAtMaxGasPrice asserts that Send properly returns the max gas
@@ -334,7 +607,7 @@ func TestTxMgrConfirmsAtHigherGasPrice(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	receipt, err := h.mgr.sendTx(ctx, tx)
+	receipt, err := h.mgr.sendTx(ctx, tx, 0)
 	require.Nil(t, err)
 	require.NotNil(t, receipt)
 	require.Equal(t, h.gasPricer.expGasFeeCap().Uint64(), receipt.GasUsed)
@@ -365,7 +638,7 @@ func TestTxMgrBlocksOnFailingRpcCalls(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	receipt, err := h.mgr.sendTx(ctx, tx)
+	receipt, err := h.mgr.sendTx(ctx, tx, 0)
 	require.Equal(t, err, context.DeadlineExceeded)
 	require.Nil(t, receipt)
 }
@@ -443,7 +716,7 @@ func TestTxMgrOnlyOnePublicationSucceeds(t *testing.T) {
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
-	receipt, err := h.mgr.sendTx(ctx, tx)
+	receipt, err := h.mgr.sendTx(ctx, tx, 0)
 	require.Nil(t, err)
 
 	require.NotNil(t, receipt)
@@ -478,7 +751,7 @@ func TestTxMgrConfirmsMinGasPriceAfterBumping(t *testing.T) {
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
-	receipt, err := h.mgr.sendTx(ctx, tx)
+	receipt, err := h.mgr.sendTx(ctx, tx, 0)
 	require.Nil(t, err)
 	require.NotNil(t, receipt)
 	require.Equal(t, h.gasPricer.expGasFeeCap().Uint64(), receipt.GasUsed)
@@ -523,7 +796,7 @@ func TestTxMgrDoesntAbortNonceTooLowAfterMiningTx(t *testing.T) {
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
-	receipt, err := h.mgr.sendTx(ctx, tx)
+	receipt, err := h.mgr.sendTx(ctx, tx, 0)
 	require.Nil(t, err)
 	require.NotNil(t, receipt)
 	require.Equal(t, h.gasPricer.expGasFeeCap().Uint64(), receipt.GasUsed)
@@ -669,6 +942,14 @@ func (b *failingBackend) PendingNonceAt(_ context.Context, _ common.Address) (ui
 	return 0, errors.New("unimplemented")
 }
 
+func (b *failingBackend) BalanceAt(_ context.Context, _ common.Address, _ *big.Int) (*big.Int, error) {
+	return nil, errors.New("unimplemented")
+}
+
+func (b *failingBackend) CallContract(_ context.Context, _ ethereum.CallMsg, _ *big.Int) ([]byte, error) {
+	return nil, errors.New("unimplemented")
+}
+
 func (b *failingBackend) ChainID(ctx context.Context) (*big.Int, error) {
 	return nil, errors.New("unimplemented")
 }
@@ -907,3 +1188,211 @@ func TestNonceReset(t *testing.T) {
 	// internal nonce tracking should be reset every 3rd tx
 	require.Equal(t, []uint64{0, 0, 1, 2, 0, 1, 2, 0}, nonces)
 }
+
+// backlogBackend is a mockBackend with a fixed mempool backlog of the sender.
+type backlogBackend struct {
+	*mockBackend
+	backlog uint64
+}
+
+func (b *backlogBackend) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	return b.backlog, nil
+}
+
+// TestCheckPoolBacklog asserts that sending is refused once the foreign mempool
+// backlog of the sender exceeds the configured maximum.
+func TestCheckPoolBacklog(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		max     uint64
+		backlog uint64
+		ownSend int64
+		// journaled is the number of txs in the journal, e.g. from before a restart
+		journaled uint64
+		err       bool
+	}{
+		{name: "disabled", max: 0, backlog: 100},
+		{name: "empty pool", max: 2, backlog: 0},
+		{name: "at max", max: 2, backlog: 2},
+		{name: "above max", max: 2, backlog: 3, err: true},
+		{name: "own sends excluded", max: 2, backlog: 5, ownSend: 3},
+		{name: "journaled txs excluded", max: 2, backlog: 5, journaled: 3},
+		{name: "journaled txs of own sends", max: 2, backlog: 5, ownSend: 3, journaled: 3},
+		{name: "above max with journal", max: 2, backlog: 6, ownSend: 1, journaled: 3, err: true},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			cfg := configWithNumConfs(1)
+			cfg.NetworkTimeout = time.Second
+			cfg.MaxPoolBacklog = test.max
+			h := newTestHarnessWithConfig(t, cfg)
+			h.mgr.backend = &backlogBackend{mockBackend: h.backend, backlog: test.backlog}
+			// the current send is counted as pending as well
+			h.mgr.pending.Store(test.ownSend + 1)
+			if test.journaled > 0 {
+				j, err := openJournal(filepath.Join(t.TempDir(), "journal.json"))
+				require.NoError(t, err)
+				defer j.Close()
+				for nonce := uint64(0); nonce < test.journaled; nonce++ {
+					require.NoError(t, j.Add(types.NewTx(&types.DynamicFeeTx{Nonce: nonce})))
+				}
+				h.mgr.journal = j
+			}
+
+			err := h.mgr.checkPoolBacklog(context.Background())
+			if test.err {
+				require.ErrorIs(t, err, ErrPoolBacklog)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+// TestAbort asserts that Abort stops all in-flight sends with the given label,
+// but not sends with other labels.
+func TestAbort(t *testing.T) {
+	t.Parallel()
+
+	h := newTestHarness(t)
+	// never mine any transaction
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	send := func(label string) chan error {
+		errCh := make(chan error, 1)
+		go func() {
+			candidate := h.createTxCandidate()
+			candidate.Label = label
+			_, err := h.mgr.Send(ctx, candidate)
+			errCh <- err
+		}()
+		return errCh
+	}
+	errA1, errA2, errB := send("a"), send("a"), send("b")
+
+	require.Eventually(t, func() bool {
+		h.mgr.inflightLock.Lock()
+		defer h.mgr.inflightLock.Unlock()
+		return len(h.mgr.inflight["a"]) == 2 && len(h.mgr.inflight["b"]) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	require.Equal(t, 2, h.mgr.Abort("a"))
+	require.ErrorIs(t, <-errA1, ErrAborted)
+	require.ErrorIs(t, <-errA2, ErrAborted)
+	require.Equal(t, 0, h.mgr.Abort("a"))
+
+	select {
+	case err := <-errB:
+		t.Fatalf("send with other label returned: %v", err)
+	default:
+	}
+	require.Equal(t, 1, h.mgr.Abort("b"))
+	require.ErrorIs(t, <-errB, ErrAborted)
+}
+
+func TestClose(t *testing.T) {
+	t.Parallel()
+
+	h := newTestHarness(t)
+	// never mine any transaction
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		return nil
+	})
+
+	errCh := make(chan error, 1)
+	go func() {
+		_, err := h.mgr.Send(context.Background(), h.createTxCandidate())
+		errCh <- err
+	}()
+	require.Eventually(t, func() bool {
+		return h.mgr.pending.Load() == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, h.mgr.Close(ctx), context.DeadlineExceeded)
+	require.ErrorIs(t, <-errCh, context.Canceled)
+
+	_, err := h.mgr.Send(context.Background(), h.createTxCandidate())
+	require.ErrorIs(t, err, ErrClosed)
+	require.NoError(t, h.mgr.Close(context.Background()))
+}
+
+// TestTxMgrLogsCandidateLabels asserts that the labels of a candidate are
+// attached to the log lines of its send.
+func TestTxMgrLogsCandidateLabels(t *testing.T) {
+	t.Parallel()
+
+	h := newTestHarness(t)
+	var mu sync.Mutex
+	var records []*log.Record
+	h.mgr.l = log.New()
+	h.mgr.l.SetHandler(log.FuncHandler(func(r *log.Record) error {
+		mu.Lock()
+		defer mu.Unlock()
+		records = append(records, r)
+		return nil
+	}))
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		txHash := tx.Hash()
+		h.backend.mine(&txHash, tx.GasFeeCap())
+		return nil
+	})
+
+	candidate := h.createTxCandidate()
+	candidate.Labels = map[string]string{"frame": "3", "channel": "abc"}
+	_, err := h.mgr.Send(context.Background(), candidate)
+	require.NoError(t, err)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.NotEmpty(t, records)
+	for _, r := range records {
+		require.Equal(t, []interface{}{"channel", "abc", "frame", "3"}, r.Ctx[:4], r.Msg)
+	}
+}
+
+// transitionMetrics records the lifecycle state transitions of sends.
+type transitionMetrics struct {
+	metrics.NoopTxMetrics
+	mu          sync.Mutex
+	transitions []string
+}
+
+func (m *transitionMetrics) RecordTxStateTransition(txmgr, from, to string, final bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.transitions = append(m.transitions, fmt.Sprintf("%s: %s -> %s", txmgr, from, to))
+}
+
+// TestTxMgrRecordsStateTransitions asserts that the state transitions of a send
+// are recorded with the name of the tx manager.
+func TestTxMgrRecordsStateTransitions(t *testing.T) {
+	t.Parallel()
+
+	h := newTestHarness(t)
+	metr := &transitionMetrics{}
+	h.mgr.metr = metr
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		txHash := tx.Hash()
+		h.backend.mine(&txHash, tx.GasFeeCap())
+		return nil
+	})
+
+	_, err := h.mgr.Send(context.Background(), h.createTxCandidate())
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"TEST:  -> crafted",
+		"TEST: crafted -> published",
+		"TEST: published -> mined",
+		"TEST: mined -> confirmed",
+	}, metr.transitions)
+}
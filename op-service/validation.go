@@ -0,0 +1,70 @@
+package op_service
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// RPCURLSchemes are the URL schemes accepted for JSON-RPC endpoints.
+var RPCURLSchemes = []string{"http", "https", "ws", "wss"}
+
+// CheckURL returns an error if rawURL is not an absolute URL with a host that
+// uses one of the given schemes.
+func CheckURL(rawURL string, schemes ...string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid URL %q: %w", rawURL, err)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("invalid URL %q: missing host", rawURL)
+	}
+	for _, scheme := range schemes {
+		if strings.EqualFold(u.Scheme, scheme) {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid URL %q: scheme must be one of %s", rawURL, strings.Join(schemes, ", "))
+}
+
+// CheckRPCURL returns an error if rawURL is neither a JSON-RPC URL with one of
+// the [RPCURLSchemes], nor the path of an IPC endpoint, which is how the RPC
+// client dials endpoints without a scheme.
+func CheckRPCURL(rawURL string) error {
+	if rawURL == "" {
+		return fmt.Errorf("invalid RPC endpoint: empty")
+	}
+	if u, err := url.Parse(rawURL); err == nil && u.Scheme == "" {
+		return nil
+	}
+	return CheckURL(rawURL, RPCURLSchemes...)
+}
+
+// CheckAddress returns an error if addr is not a hex encoded address. Mixed-case
+// addresses must also pass the EIP-55 checksum, to catch typos. The zero address
+// is allowed, use CheckNonZeroAddress where it is invalid.
+func CheckAddress(addr string) error {
+	if !common.IsHexAddress(addr) {
+		return fmt.Errorf("invalid address %q", addr)
+	}
+	hexPart := strings.TrimPrefix(strings.TrimPrefix(addr, "0x"), "0X")
+	if hexPart != strings.ToLower(hexPart) && hexPart != strings.ToUpper(hexPart) &&
+		common.HexToAddress(addr).Hex() != "0x"+hexPart {
+		return fmt.Errorf("invalid address %q: checksum mismatch", addr)
+	}
+	return nil
+}
+
+// CheckNonZeroAddress is like CheckAddress, but also rejects the zero address,
+// which is what an unset address would be parsed as.
+func CheckNonZeroAddress(addr string) error {
+	if err := CheckAddress(addr); err != nil {
+		return err
+	}
+	if common.HexToAddress(addr) == (common.Address{}) {
+		return fmt.Errorf("invalid address %q: zero address", addr)
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package op_service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckURL(t *testing.T) {
+	for _, valid := range []string{"http://localhost:8545", "https://example.com", "ws://127.0.0.1:8546", "WSS://example.com/rpc"} {
+		require.NoError(t, CheckURL(valid, RPCURLSchemes...), valid)
+	}
+	for _, invalid := range []string{"", "localhost:8545", "ftp://example.com", "http://", "/tmp/geth.ipc"} {
+		require.Error(t, CheckURL(invalid, RPCURLSchemes...), invalid)
+	}
+}
+
+func TestCheckRPCURL(t *testing.T) {
+	for _, valid := range []string{"http://localhost:8545", "wss://example.com/rpc", "/tmp/geth.ipc", "geth.ipc"} {
+		require.NoError(t, CheckRPCURL(valid), valid)
+	}
+	for _, invalid := range []string{"", "localhost:8545", "ftp://example.com", "http://"} {
+		require.Error(t, CheckRPCURL(invalid), invalid)
+	}
+}
+
+func TestCheckAddress(t *testing.T) {
+	for _, valid := range []string{
+		"0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed",
+		"0x5AAEB6053F3E94C9B9A09F33669435E7EF1BEAED",
+		"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+	} {
+		require.NoError(t, CheckAddress(valid), valid)
+	}
+	for _, invalid := range []string{
+		"",
+		"0x1234",
+		"0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaeg",
+		"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeD",
+	} {
+		require.Error(t, CheckAddress(invalid), invalid)
+		require.Error(t, CheckNonZeroAddress(invalid), invalid)
+	}
+
+	zero := "0x0000000000000000000000000000000000000000"
+	require.NoError(t, CheckAddress(zero))
+	require.Error(t, CheckNonZeroAddress(zero))
+	require.NoError(t, CheckNonZeroAddress("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"))
+}
@@ -2,8 +2,9 @@ package client
 
 import (
 	"errors"
+	"fmt"
 
-	"github.com/urfave/cli"
+	"github.com/urfave/cli/v2"
 
 	opservice "github.com/ethereum-optimism/optimism/op-service"
 	optls "github.com/ethereum-optimism/optimism/op-service/tls"
@@ -17,15 +18,17 @@ const (
 func CLIFlags(envPrefix string) []cli.Flag {
 	envPrefix += "_SIGNER"
 	flags := []cli.Flag{
-		cli.StringFlag{
-			Name:   EndpointFlagName,
-			Usage:  "Signer endpoint the client will connect to",
-			EnvVar: opservice.PrefixEnvVar(envPrefix, "ENDPOINT"),
+		&cli.StringFlag{
+			Name:     EndpointFlagName,
+			Usage:    "Signer endpoint the client will connect to",
+			EnvVars:  []string{opservice.PrefixEnvVar(envPrefix, "ENDPOINT")},
+			Category: opservice.SigningCategory,
 		},
-		cli.StringFlag{
-			Name:   AddressFlagName,
-			Usage:  "Address the signer is signing transactions for",
-			EnvVar: opservice.PrefixEnvVar(envPrefix, "ADDRESS"),
+		&cli.StringFlag{
+			Name:     AddressFlagName,
+			Usage:    "Address the signer is signing transactions for",
+			EnvVars:  []string{opservice.PrefixEnvVar(envPrefix, "ADDRESS")},
+			Category: opservice.SigningCategory,
 		},
 	}
 	flags = append(flags, optls.CLIFlagsWithFlagPrefix(envPrefix, "signer")...)
@@ -45,6 +48,16 @@ func (c CLIConfig) Check() error {
 	if !((c.Endpoint == "" && c.Address == "") || (c.Endpoint != "" && c.Address != "")) {
 		return errors.New("signer endpoint and address must both be set or not set")
 	}
+	if c.Endpoint != "" {
+		if err := opservice.CheckURL(c.Endpoint, "http", "https"); err != nil {
+			return fmt.Errorf("signer endpoint: %w", err)
+		}
+	}
+	if c.Address != "" {
+		if err := opservice.CheckNonZeroAddress(c.Address); err != nil {
+			return fmt.Errorf("signer address: %w", err)
+		}
+	}
 	return nil
 }
 
@@ -15,7 +15,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/rpc"
-	"github.com/urfave/cli"
+	"github.com/urfave/cli/v2"
 
 	"github.com/ethereum-optimism/optimism/op-node/client"
 	opservice "github.com/ethereum-optimism/optimism/op-service"
@@ -28,60 +28,60 @@ import (
 const envVarPrefix = "OP_WHEEL"
 
 var (
-	GlobalGethLogLvlFlag = cli.StringFlag{
-		Name:   "geth-log-level",
-		Usage:  "Set the global geth logging level",
-		EnvVar: opservice.PrefixEnvVar("OP_WHEEL", "GETH_LOG_LEVEL"),
-		Value:  "error",
+	GlobalGethLogLvlFlag = &cli.StringFlag{
+		Name:    "geth-log-level",
+		Usage:   "Set the global geth logging level",
+		EnvVars: []string{opservice.PrefixEnvVar("OP_WHEEL", "GETH_LOG_LEVEL")},
+		Value:   "error",
 	}
-	DataDirFlag = cli.StringFlag{
+	DataDirFlag = &cli.StringFlag{
 		Name:      "data-dir",
 		Usage:     "Geth data dir location.",
 		Required:  true,
 		TakesFile: true,
-		EnvVar:    opservice.PrefixEnvVar(envVarPrefix, "DATA_DIR"),
+		EnvVars:   []string{opservice.PrefixEnvVar(envVarPrefix, "DATA_DIR")},
 	}
-	EngineEndpoint = cli.StringFlag{
+	EngineEndpoint = &cli.StringFlag{
 		Name:     "engine",
 		Usage:    "Engine API RPC endpoint, can be HTTP/WS/IPC",
 		Required: true,
-		EnvVar:   opservice.PrefixEnvVar(envVarPrefix, "ENGINE"),
+		EnvVars:  []string{opservice.PrefixEnvVar(envVarPrefix, "ENGINE")},
 	}
-	EngineJWTPath = cli.StringFlag{
+	EngineJWTPath = &cli.StringFlag{
 		Name:      "engine.jwt-secret",
 		Usage:     "Path to JWT secret file used to authenticate Engine API communication with.",
 		Required:  true,
 		TakesFile: true,
-		EnvVar:    opservice.PrefixEnvVar(envVarPrefix, "ENGINE_JWT_SECRET"),
+		EnvVars:   []string{opservice.PrefixEnvVar(envVarPrefix, "ENGINE_JWT_SECRET")},
 	}
-	FeeRecipientFlag = cli.GenericFlag{
-		Name:   "fee-recipient",
-		Usage:  "fee-recipient of the block building",
-		EnvVar: opservice.PrefixEnvVar(envVarPrefix, "FEE_RECIPIENT"),
-		Value:  &TextFlag[*common.Address]{Value: &common.Address{1: 0x13, 2: 0x37}},
+	FeeRecipientFlag = &cli.GenericFlag{
+		Name:    "fee-recipient",
+		Usage:   "fee-recipient of the block building",
+		EnvVars: []string{opservice.PrefixEnvVar(envVarPrefix, "FEE_RECIPIENT")},
+		Value:   &TextFlag[*common.Address]{Value: &common.Address{1: 0x13, 2: 0x37}},
 	}
-	RandaoFlag = cli.GenericFlag{
-		Name:   "randao",
-		Usage:  "randao value of the block building",
-		EnvVar: opservice.PrefixEnvVar(envVarPrefix, "RANDAO"),
-		Value:  &TextFlag[*common.Hash]{Value: &common.Hash{1: 0x13, 2: 0x37}},
+	RandaoFlag = &cli.GenericFlag{
+		Name:    "randao",
+		Usage:   "randao value of the block building",
+		EnvVars: []string{opservice.PrefixEnvVar(envVarPrefix, "RANDAO")},
+		Value:   &TextFlag[*common.Hash]{Value: &common.Hash{1: 0x13, 2: 0x37}},
 	}
-	BlockTimeFlag = cli.Uint64Flag{
-		Name:   "block-time",
-		Usage:  "block time, interval of timestamps between blocks to build, in seconds",
-		EnvVar: opservice.PrefixEnvVar(envVarPrefix, "BLOCK_TIME"),
-		Value:  12,
+	BlockTimeFlag = &cli.Uint64Flag{
+		Name:    "block-time",
+		Usage:   "block time, interval of timestamps between blocks to build, in seconds",
+		EnvVars: []string{opservice.PrefixEnvVar(envVarPrefix, "BLOCK_TIME")},
+		Value:   12,
 	}
-	BuildingTime = cli.DurationFlag{
-		Name:   "building-time",
-		Usage:  "duration of of block building, this should be set to something lower than the block time.",
-		EnvVar: opservice.PrefixEnvVar(envVarPrefix, "BUILDING_TIME"),
-		Value:  time.Second * 6,
+	BuildingTime = &cli.DurationFlag{
+		Name:    "building-time",
+		Usage:   "duration of of block building, this should be set to something lower than the block time.",
+		EnvVars: []string{opservice.PrefixEnvVar(envVarPrefix, "BUILDING_TIME")},
+		Value:   time.Second * 6,
 	}
-	AllowGaps = cli.BoolFlag{
-		Name:   "allow-gaps",
-		Usage:  "allow gaps in block building, like missed slots on the beacon chain.",
-		EnvVar: opservice.PrefixEnvVar(envVarPrefix, "ALLOW_GAPS"),
+	AllowGaps = &cli.BoolFlag{
+		Name:    "allow-gaps",
+		Usage:   "allow gaps in block building, like missed slots on the beacon chain.",
+		EnvVars: []string{opservice.PrefixEnvVar(envVarPrefix, "ALLOW_GAPS")},
 	}
 )
 
@@ -165,25 +165,25 @@ func (a *TextFlag[T]) Get() T {
 
 var _ cli.Generic = (*TextFlag[*common.Address])(nil)
 
-func textFlag[T Text](name string, usage string, value T) cli.GenericFlag {
-	return cli.GenericFlag{
+func textFlag[T Text](name string, usage string, value T) *cli.GenericFlag {
+	return &cli.GenericFlag{
 		Name:     name,
 		Usage:    usage,
-		EnvVar:   opservice.PrefixEnvVar(envVarPrefix, strings.ToUpper(name)),
+		EnvVars:  []string{opservice.PrefixEnvVar(envVarPrefix, strings.ToUpper(name))},
 		Required: true,
 		Value:    &TextFlag[T]{Value: value},
 	}
 }
 
-func addrFlag(name string, usage string) cli.GenericFlag {
+func addrFlag(name string, usage string) *cli.GenericFlag {
 	return textFlag[*common.Address](name, usage, new(common.Address))
 }
 
-func hashFlag(name string, usage string) cli.GenericFlag {
+func hashFlag(name string, usage string) *cli.GenericFlag {
 	return textFlag[*common.Hash](name, usage, new(common.Hash))
 }
 
-func bigFlag(name string, usage string) cli.GenericFlag {
+func bigFlag(name string, usage string) *cli.GenericFlag {
 	return textFlag[*big.Int](name, usage, new(big.Int))
 }
 
@@ -200,7 +200,7 @@ func bigFlagValue(name string, ctx *cli.Context) *big.Int {
 }
 
 var (
-	CheatStorageGetCmd = cli.Command{
+	CheatStorageGetCmd = &cli.Command{
 		Name:    "get",
 		Aliases: []string{"read"},
 		Flags: []cli.Flag{
@@ -212,7 +212,7 @@ var (
 			return ch.RunAndClose(cheat.StorageGet(addrFlagValue("address", ctx), hashFlagValue("key", ctx), ctx.App.Writer))
 		}),
 	}
-	CheatStorageSetCmd = cli.Command{
+	CheatStorageSetCmd = &cli.Command{
 		Name:    "set",
 		Aliases: []string{"write"},
 		Flags: []cli.Flag{
@@ -225,7 +225,7 @@ var (
 			return ch.RunAndClose(cheat.StorageSet(addrFlagValue("address", ctx), hashFlagValue("key", ctx), hashFlagValue("value", ctx)))
 		}),
 	}
-	CheatStorageReadAll = cli.Command{
+	CheatStorageReadAll = &cli.Command{
 		Name:    "read-all",
 		Aliases: []string{"get-all"},
 		Usage:   "Read all storage of the given account",
@@ -234,7 +234,7 @@ var (
 			return ch.RunAndClose(cheat.StorageReadAll(addrFlagValue("address", ctx), ctx.App.Writer))
 		}),
 	}
-	CheatStorageDiffCmd = cli.Command{
+	CheatStorageDiffCmd = &cli.Command{
 		Name:  "diff",
 		Usage: "Diff the storage of accounts A and B",
 		Flags: []cli.Flag{DataDirFlag, hashFlag("a", "address of account A"), hashFlag("b", "address of account B")},
@@ -242,7 +242,7 @@ var (
 			return ch.RunAndClose(cheat.StorageDiff(ctx.App.Writer, addrFlagValue("a", ctx), addrFlagValue("b", ctx)))
 		}),
 	}
-	CheatStoragePatchCmd = cli.Command{
+	CheatStoragePatchCmd = &cli.Command{
 		Name:  "patch",
 		Usage: "Apply storage patch from STDIN to the given account address",
 		Flags: []cli.Flag{DataDirFlag, addrFlag("address", "Address to patch storage of")},
@@ -250,9 +250,9 @@ var (
 			return ch.RunAndClose(cheat.StoragePatch(os.Stdin, addrFlagValue("address", ctx)))
 		}),
 	}
-	CheatStorageCmd = cli.Command{
+	CheatStorageCmd = &cli.Command{
 		Name: "storage",
-		Subcommands: []cli.Command{
+		Subcommands: []*cli.Command{
 			CheatStorageGetCmd,
 			CheatStorageSetCmd,
 			CheatStorageReadAll,
@@ -260,7 +260,7 @@ var (
 			CheatStoragePatchCmd,
 		},
 	}
-	CheatSetBalanceCmd = cli.Command{
+	CheatSetBalanceCmd = &cli.Command{
 		Name: "balance",
 		Flags: []cli.Flag{
 			DataDirFlag,
@@ -271,7 +271,7 @@ var (
 			return ch.RunAndClose(cheat.SetBalance(addrFlagValue("address", ctx), bigFlagValue("balance", ctx)))
 		}),
 	}
-	CheatSetNonceCmd = cli.Command{
+	CheatSetNonceCmd = &cli.Command{
 		Name: "nonce",
 		Flags: []cli.Flag{
 			DataDirFlag,
@@ -282,15 +282,15 @@ var (
 			return ch.RunAndClose(cheat.SetNonce(addrFlagValue("address", ctx), bigFlagValue("balance", ctx).Uint64()))
 		}),
 	}
-	CheatOvmOwnersCmd = cli.Command{
+	CheatOvmOwnersCmd = &cli.Command{
 		Name: "ovm-owners",
 		Flags: []cli.Flag{
 			DataDirFlag,
-			cli.StringFlag{
+			&cli.StringFlag{
 				Name:     "config",
 				Usage:    "Path to JSON config of OVM address replacements to apply.",
 				Required: true,
-				EnvVar:   opservice.PrefixEnvVar(envVarPrefix, "OVM_OWNERS"),
+				EnvVars:  []string{opservice.PrefixEnvVar(envVarPrefix, "OVM_OWNERS")},
 				Value:    "ovm-owners.json",
 			},
 		},
@@ -306,7 +306,7 @@ var (
 			return ch.RunAndClose(cheat.OvmOwners(&conf))
 		}),
 	}
-	CheatPrintHeadBlock = cli.Command{
+	CheatPrintHeadBlock = &cli.Command{
 		Name:  "head-block",
 		Usage: "dump head block as JSON",
 		Flags: []cli.Flag{
@@ -325,7 +325,7 @@ var (
 			})
 		}),
 	}
-	CheatPrintHeadHeader = cli.Command{
+	CheatPrintHeadHeader = &cli.Command{
 		Name:  "head-header",
 		Usage: "dump head header as JSON",
 		Flags: []cli.Flag{
@@ -337,7 +337,7 @@ var (
 			return enc.Encode(rawdb.ReadHeadHeader(db))
 		}),
 	}
-	EngineBlockCmd = cli.Command{
+	EngineBlockCmd = &cli.Command{
 		Name:  "block",
 		Usage: "build the next block using the Engine API",
 		Flags: []cli.Flag{
@@ -362,7 +362,7 @@ var (
 			return err
 		}),
 	}
-	EngineAutoCmd = cli.Command{
+	EngineAutoCmd = &cli.Command{
 		Name:        "auto",
 		Usage:       "Run a proof-of-nothing chain with fixed block time.",
 		Description: "The block time can be changed. The execution engine must be synced to a post-Merge state first.",
@@ -371,7 +371,7 @@ var (
 			FeeRecipientFlag, RandaoFlag, BlockTimeFlag, BuildingTime, AllowGaps,
 		}, oplog.CLIFlags(envVarPrefix)...), opmetrics.CLIFlags(envVarPrefix)...),
 		Action: EngineAction(func(ctx *cli.Context, client client.RPC) error {
-			logCfg := oplog.ReadLocalCLIConfig(ctx)
+			logCfg := oplog.ReadCLIConfig(ctx)
 			if err := logCfg.Check(); err != nil {
 				return fmt.Errorf("failed to parse log configuration: %w", err)
 			}
@@ -380,7 +380,10 @@ var (
 			settings := ParseBuildingArgs(ctx)
 			// TODO: finalize/safe flag
 
-			metricsCfg := opmetrics.ReadLocalCLIConfig(ctx)
+			metricsCfg := opmetrics.ReadCLIConfig(ctx)
+			if err := metricsCfg.Check(); err != nil {
+				return fmt.Errorf("failed to parse metrics configuration: %w", err)
+			}
 
 			return opservice.CloseAction(func(ctx context.Context, shutdown <-chan struct{}) error {
 				registry := opmetrics.NewRegistry()
@@ -388,7 +391,7 @@ var (
 				if metricsCfg.Enabled {
 					l.Info("starting metrics server", "addr", metricsCfg.ListenAddr, "port", metricsCfg.ListenPort)
 					go func() {
-						if err := opmetrics.ListenAndServe(ctx, registry, metricsCfg.ListenAddr, metricsCfg.ListenPort); err != nil {
+						if err := opmetrics.ListenAndServe(ctx, registry, metricsCfg.ListenAddr, metricsCfg.ListenPort, metricsCfg.Auth.ServerOptions()...); err != nil {
 							l.Error("error starting metrics server", err)
 						}
 					}()
@@ -397,7 +400,7 @@ var (
 			})
 		}),
 	}
-	EngineStatusCmd = cli.Command{
+	EngineStatusCmd = &cli.Command{
 		Name:  "status",
 		Flags: []cli.Flag{EngineEndpoint, EngineJWTPath},
 		Action: EngineAction(func(ctx *cli.Context, client client.RPC) error {
@@ -410,15 +413,15 @@ var (
 			return enc.Encode(stat)
 		}),
 	}
-	EngineCopyCmd = cli.Command{
+	EngineCopyCmd = &cli.Command{
 		Name: "copy",
 		Flags: []cli.Flag{
 			EngineEndpoint, EngineJWTPath,
-			cli.StringFlag{
+			&cli.StringFlag{
 				Name:     "source",
 				Usage:    "Unauthenticated regular eth JSON RPC to pull block data from, can be HTTP/WS/IPC.",
 				Required: true,
-				EnvVar:   opservice.PrefixEnvVar(envVarPrefix, "ENGINE"),
+				EnvVars:  []string{opservice.PrefixEnvVar(envVarPrefix, "ENGINE")},
 			},
 		},
 		Action: EngineAction(func(ctx *cli.Context, dest client.RPC) error {
@@ -432,12 +435,12 @@ var (
 	}
 )
 
-var CheatCmd = cli.Command{
+var CheatCmd = &cli.Command{
 	Name:  "cheat",
 	Usage: "Cheating commands to modify a Geth database.",
 	Description: "Each sub-command opens a Geth database, applies the cheat, and then saves and closes the database." +
 		"The Geth node will live in its own false reality, other nodes cannot sync the cheated state if they process the blocks.",
-	Subcommands: []cli.Command{
+	Subcommands: []*cli.Command{
 		CheatStorageCmd,
 		CheatSetBalanceCmd,
 		CheatSetNonceCmd,
@@ -447,11 +450,11 @@ var CheatCmd = cli.Command{
 	},
 }
 
-var EngineCmd = cli.Command{
+var EngineCmd = &cli.Command{
 	Name:        "engine",
 	Usage:       "Engine API commands to build/reorg/finalize blocks.",
 	Description: "Each sub-command dials the engine API endpoint (with provided JWT secret) and then runs the action",
-	Subcommands: []cli.Command{
+	Subcommands: []*cli.Command{
 		EngineBlockCmd,
 		EngineAutoCmd,
 		EngineStatusCmd,